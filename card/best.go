@@ -0,0 +1,77 @@
+package card
+
+import (
+	"errors"
+	"fmt"
+)
+
+// BestCombination picks the strongest ValidCombinationSize-card combination
+// out of the given cards, e.g. 2 hole cards and 5 board cards in Texas Hold'em
+func BestCombination(cards []Card) (PokerCombination, error) {
+	if len(cards) < ValidCombinationSize {
+		return nil, errors.New(fmt.Sprintf("at least %d cards are required, got %d", ValidCombinationSize, len(cards)))
+	}
+
+	var best PokerCombination
+	indices := make([]int, ValidCombinationSize)
+	for i := range indices {
+		indices[i] = i
+	}
+	for {
+		hand := make([]Card, ValidCombinationSize)
+		for i, index := range indices {
+			hand[i] = cards[index]
+		}
+		combination, err := CombinationOf(hand)
+		if err != nil {
+			return nil, err
+		}
+		if Compare(combination, best) > 0 {
+			best = combination
+		}
+
+		if !nextIndices(indices, len(cards)) {
+			return best, nil
+		}
+	}
+}
+
+// nextIndices advances indices to the next k-subset of [0, n) in lexicographic order
+// and reports whether there was one
+func nextIndices(indices []int, n int) bool {
+	k := len(indices)
+	i := k - 1
+	for i >= 0 && indices[i] == n-k+i {
+		i--
+	}
+	if i < 0 {
+		return false
+	}
+	indices[i]++
+	for j := i + 1; j < k; j++ {
+		indices[j] = indices[j-1] + 1
+	}
+	return true
+}
+
+// EvaluateBest returns the strength of the strongest ValidCombinationSize-card hand out of cards
+// without allocating, or 0 if there are fewer cards than that
+func EvaluateBest(cards []EncodedCard) int {
+	if len(cards) < ValidCombinationSize {
+		return 0
+	}
+	best := 0
+	var indices [ValidCombinationSize]int
+	for i := range indices {
+		indices[i] = i
+	}
+	for {
+		strength := Evaluate(cards[indices[0]], cards[indices[1]], cards[indices[2]], cards[indices[3]], cards[indices[4]])
+		if strength > best {
+			best = strength
+		}
+		if !nextIndices(indices[:], len(cards)) {
+			return best
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package card
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestBestCombination(t *testing.T) {
+	t.Run("five cards are classified as they are", func(t *testing.T) {
+		cards := cardsOf(t, "♠2", "♠5", "♠A", "♠K", "♦K")
+		best, err := BestCombination(cards)
+		require.NoError(t, err)
+		assert.Equal(t, CombinationPairName, best.Name())
+		assert.Equal(t, cards, best.Cards())
+	})
+	t.Run("hole cards and board make a flush", func(t *testing.T) {
+		cards := cardsOf(t, "♥A", "♥7", "♥2", "♥9", "♣9", "♥J", "♦3")
+		best, err := BestCombination(cards)
+		require.NoError(t, err)
+		assert.Equal(t, CombinationFlush, best.Name())
+		assert.Equal(t, []int{14, 11, 9, 7, 2}, best.Kickers())
+	})
+	t.Run("best kickers are chosen", func(t *testing.T) {
+		cards := cardsOf(t, "♠2", "♠5", "♦K", "♠K", "♦Q", "♣A", "♥3")
+		best, err := BestCombination(cards)
+		require.NoError(t, err)
+		assert.Equal(t, CombinationPairName, best.Name())
+		assert.Equal(t, []int{13, 14, 12, 5}, best.Kickers())
+	})
+	t.Run("higher straight is preferred", func(t *testing.T) {
+		cards := cardsOf(t, "♠A", "♦2", "♥3", "♠4", "♦5", "♣6", "♥7")
+		best, err := BestCombination(cards)
+		require.NoError(t, err)
+		assert.Equal(t, CombinationStraight, best.Name())
+		assert.Equal(t, []int{7}, best.Kickers())
+	})
+	t.Run("less than five cards produce error", func(t *testing.T) {
+		best, err := BestCombination(cardsOf(t, "♠A", "♦2", "♥3", "♠4"))
+		require.Error(t, err)
+		assert.Nil(t, best)
+	})
+}
+
+func TestBestCombinationWithJokers(t *testing.T) {
+	best, err := BestCombination(cardsOf(t, "♠K", "♦K", "♥K", "♣2", "♦7", JokerUnicode, JokerUnicode))
+	require.NoError(t, err)
+	assert.Equal(t, RankFiveOfAKind, best.Rank())
+	assert.Equal(t, []int{13}, best.Kickers())
+}
+
+func TestEvaluateBest(t *testing.T) {
+	t.Run("agrees with BestCombination", func(t *testing.T) {
+		cards := cardsOf(t, "♠2", "♠5", "♦K", "♠K", "♦Q", "♣A", "♥3")
+		best, err := BestCombination(cards)
+		require.NoError(t, err)
+		assert.Equal(t, best.Strength(), EvaluateBest(encodeAll(t, cards)))
+	})
+	t.Run("does not allocate", func(t *testing.T) {
+		encoded := encodeAll(t, cardsOf(t, "♥A", "♥7", "♥2", "♥9", "♣9", "♥J", "♦3"))
+		allocations := testing.AllocsPerRun(100, func() {
+			EvaluateBest(encoded)
+		})
+		assert.Equal(t, 0.0, allocations)
+	})
+	t.Run("less than five cards", func(t *testing.T) {
+		assert.Equal(t, 0, EvaluateBest(encodeAll(t, cardsOf(t, "♠A", "♦2", "♥3", "♠4"))))
+	})
+}
+
+func Test_nextIndices(t *testing.T) {
+	indices := []int{0, 1, 2}
+	count := 1
+	for nextIndices(indices, 6) {
+		count++
+	}
+	assert.Equal(t, 20, count)
+	assert.Equal(t, []int{3, 4, 5}, indices)
+}
@@ -3,46 +3,84 @@ package card
 import (
 	"errors"
 	"fmt"
-	"log"
 	"math/rand"
-	"strconv"
 	"strings"
+	"unicode"
 	"unicode/utf8"
 )
 
+// Suit of a card. The zero value is not a valid suit
+type Suit uint8
+
+const SuitCount = 4
+
 const (
-	SuitCount = 4
+	SuitDiamonds Suit = iota + 1
+	SuitClubs
+	SuitHearts
+	SuitSpades
+)
 
-	SuitDiamonds        = "diamonds"
+const (
 	SuitDiamondsUnicode = "\u2666"
+	SuitClubsUnicode    = "\u2663"
+	SuitHeartsUnicode   = "\u2665"
+	SuitSpadesUnicode   = "\u2660"
+)
 
-	SuitClubs        = "clubs"
-	SuitClubsUnicode = "\u2663"
+var suitNames = map[Suit]string{
+	SuitDiamonds: "diamonds",
+	SuitClubs:    "clubs",
+	SuitHearts:   "hearts",
+	SuitSpades:   "spades",
+}
 
-	SuitHearts        = "hearts"
-	SuitHeartsUnicode = "\u2665"
+// Face of a card. Faces are numbered by their numeric value, so Face2 is 2 and FaceAce is 14.
+// The zero value is not a valid face
+type Face uint8
 
-	SuitSpades        = "spades"
-	SuitSpadesUnicode = "\u2660"
-)
+const FaceCount = 13
 
 const (
-	FaceCount = 13
-	Face2     = "2"
-	Face3     = "3"
-	Face4     = "4"
-	Face5     = "5"
-	Face6     = "6"
-	Face7     = "7"
-	Face8     = "8"
-	Face9     = "9"
-	Face10    = "10"
-	FaceJack  = "J"
-	FaceQueen = "Q"
-	FaceKing  = "K"
-	FaceAce   = "A"
+	Face2 Face = iota + 2
+	Face3
+	Face4
+	Face5
+	Face6
+	Face7
+	Face8
+	Face9
+	Face10
+	FaceJack
+	FaceQueen
+	FaceKing
+	FaceAce
+	// FaceJoker is only used by Joker, it is not a valid face of a regular card
+	FaceJoker
 )
 
+// JokerUnicode is the black joker of the Unicode Playing Cards block
+const JokerUnicode = "\U0001F0CF"
+
+// Joker is a card without a suit
+var Joker = Card{Face: FaceJoker}
+
+var faceNames = map[Face]string{
+	Face2:     "2",
+	Face3:     "3",
+	Face4:     "4",
+	Face5:     "5",
+	Face6:     "6",
+	Face7:     "7",
+	Face8:     "8",
+	Face9:     "9",
+	Face10:    "10",
+	FaceJack:  "J",
+	FaceQueen: "Q",
+	FaceKing:  "K",
+	FaceAce:   "A",
+}
+
 const (
 	NumericValueJack  = 11
 	NumericValueQueen = 12
@@ -51,38 +89,90 @@ const (
 )
 
 type Card struct {
-	Suit string
-	Face string
+	Suit Suit
+	Face Face
 }
 
-func isValidSuit(suit string) bool {
-	switch suit {
-	case SuitClubs, SuitDiamonds, SuitHearts, SuitSpades:
-		return true
-	default:
-		return false
+// Suits returns every valid suit
+func Suits() []Suit {
+	return []Suit{SuitDiamonds, SuitClubs, SuitHearts, SuitSpades}
+}
+
+// Faces returns every valid face from the lowest to the highest
+func Faces() []Face {
+	return []Face{Face2, Face3, Face4, Face5, Face6, Face7, Face8, Face9, Face10, FaceJack, FaceQueen, FaceKing, FaceAce}
+}
+
+func (s Suit) IsValid() bool {
+	return s >= SuitDiamonds && s <= SuitSpades
+}
+
+func (s Suit) String() string {
+	if name, ok := suitNames[s]; ok {
+		return name
 	}
+	return fmt.Sprintf("Suit(%d)", uint8(s))
 }
 
-func isValidFace(face string) bool {
-	switch face {
-	case Face2, Face3, Face4, Face5, Face6, Face7, Face8, Face9, Face10, FaceJack, FaceQueen, FaceKing, FaceAce:
-		return true
-	default:
-		return false
+// ParseSuit returns the suit with the given name, e.g. "spades"
+func ParseSuit(name string) (Suit, error) {
+	for suit, suitName := range suitNames {
+		if suitName == name {
+			return suit, nil
+		}
 	}
+	return 0, errors.New(fmt.Sprintf("unrecognized suit %s", name))
+}
+
+func (f Face) IsValid() bool {
+	return f >= Face2 && f <= FaceAce
 }
 
-func randomSuit(rand rand.Rand) string {
+func (f Face) String() string {
+	if name, ok := faceNames[f]; ok {
+		return name
+	}
+	return fmt.Sprintf("Face(%d)", uint8(f))
+}
+
+// ParseFace returns the face with the given name, e.g. "10" or "J"
+func ParseFace(name string) (Face, error) {
+	for face, faceName := range faceNames {
+		if faceName == name {
+			return face, nil
+		}
+	}
+	return 0, errors.New(fmt.Sprintf("unrecognized face %s", name))
+}
+
+func isValidSuit(suit Suit) bool {
+	return suit.IsValid()
+}
+
+func isValidFace(face Face) bool {
+	return face.IsValid()
+}
+
+func randomSuit(rand rand.Rand) Suit {
 	index := rand.Intn(SuitCount)
-	suits := []string{SuitHearts, SuitDiamonds, SuitSpades, SuitClubs}
+	suits := []Suit{SuitHearts, SuitDiamonds, SuitSpades, SuitClubs}
 	return suits[index]
 }
 
-func randomFace(random rand.Rand) string {
+func randomFace(random rand.Rand) Face {
 	index := random.Intn(FaceCount)
-	suits := []string{Face2, Face3, Face4, Face5, Face6, Face7, Face8, Face9, Face10, FaceJack, FaceQueen, FaceKing, FaceAce}
-	return suits[index]
+	return Faces()[index]
+}
+
+func (c Card) String() string {
+	if c.IsJoker() {
+		return longJoker
+	}
+	return fmt.Sprintf("%s of %s", c.Face, c.Suit)
+}
+
+func (c Card) IsJoker() bool {
+	return c == Joker
 }
 
 func (c Card) SuitUnicode() (string, error) {
@@ -101,6 +191,9 @@ func (c Card) SuitUnicode() (string, error) {
 }
 
 func (c Card) ShortRepresentation() (string, error) {
+	if c.IsJoker() {
+		return JokerUnicode, nil
+	}
 	unicode, err := c.SuitUnicode()
 	if err != nil {
 		return "", err
@@ -111,48 +204,40 @@ func (c Card) ShortRepresentation() (string, error) {
 	return fmt.Sprintf("%s%s", unicode, c.Face), nil
 }
 
-func (c Card) IsNumeric() bool {
-	switch c.Face {
-	case Face2, Face3, Face4, Face5, Face6, Face7, Face8, Face9, Face10:
-		return true
-	default:
-		return false
+// Byte packs a valid card into a single byte, the suit in the high nibble and the face in the low one
+func (c Card) Byte() byte {
+	return byte(c.Suit)<<4 | byte(c.Face)
+}
+
+// FromByte is the inverse of Card.Byte
+func FromByte(b byte) (*Card, error) {
+	if b == Joker.Byte() {
+		joker := Joker
+		return &joker, nil
 	}
+	return New(Suit(b>>4), Face(b&0x0F))
+}
+
+func (c Card) IsNumeric() bool {
+	return c.Face >= Face2 && c.Face <= Face10
 }
 
 func (c Card) StrictNumericValue() (int, error) {
 	if c.IsNumeric() {
-		atoi, err := strconv.Atoi(c.Face)
-		if err != nil {
-			return 0, err
-		}
-		return atoi, nil
+		return int(c.Face), nil
 	} else {
 		return 0, errors.New("not-numeric cards cannot have numeric value")
 	}
 }
 
 func (c Card) NumericValue() int {
-	if c.IsNumeric() {
-		numericValue, _ := c.StrictNumericValue()
-		return numericValue
-	} else {
-		switch c.Face {
-		case FaceJack:
-			return NumericValueJack
-		case FaceQueen:
-			return NumericValueQueen
-		case FaceKing:
-			return NumericValueKing
-		case FaceAce:
-			return NumericValueAce
-		default:
-			return 0
-		}
+	if isValidFace(c.Face) {
+		return int(c.Face)
 	}
+	return 0
 }
 
-func New(suit string, face string) (*Card, error) {
+func New(suit Suit, face Face) (*Card, error) {
 	if isValidSuit(suit) && isValidFace(face) {
 		return &Card{
 			Suit: suit,
@@ -163,25 +248,63 @@ func New(suit string, face string) (*Card, error) {
 	}
 }
 
+// ParseError describes why a card could not be parsed.
+// Offset is the byte offset inside Input where the problem was found
+type ParseError struct {
+	Input  string
+	Offset int
+	Reason string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("cannot parse card %q at offset %d: %s", e.Input, e.Offset, e.Reason)
+}
+
+// trimmedInput strips surrounding whitespace and returns the offset of the first remaining byte
+func trimmedInput(representation string) (string, int) {
+	trimmed := strings.TrimLeftFunc(representation, unicode.IsSpace)
+	offset := len(representation) - len(trimmed)
+	return strings.TrimRightFunc(trimmed, unicode.IsSpace), offset
+}
+
+// FromShortRepresentation parses cards like "♠A" or "♦10". Surrounding whitespace is ignored.
+// Errors are of type *ParseError
 func FromShortRepresentation(representation string) (*Card, error) {
-	representation = strings.Trim(representation, "\n")
-	suitUnicode, size := utf8.DecodeRuneInString(representation)
-	face := representation[size:]
+	trimmed, offset := trimmedInput(representation)
+	if trimmed == "" {
+		return nil, &ParseError{Input: representation, Offset: offset, Reason: "empty card"}
+	}
+	if trimmed == JokerUnicode {
+		joker := Joker
+		return &joker, nil
+	}
+
+	suitUnicode, size := utf8.DecodeRuneInString(trimmed)
 	suit, err := SuitOfUnicodeSymbol(string(suitUnicode))
-	log.Printf("parsing cardCSVEntry {%s}, suit {%s}, face {%s}, len {%d}",
-		representation,
-		suit,
-		face,
-		len(representation),
-	)
 	if err != nil {
-		return nil, err
+		return nil, &ParseError{
+			Input:  representation,
+			Offset: offset,
+			Reason: fmt.Sprintf("unrecognized suit %q", suitUnicode),
+		}
+	}
+
+	faceName := trimmed[size:]
+	if faceName == "" {
+		return nil, &ParseError{Input: representation, Offset: offset + size, Reason: "missing face"}
+	}
+	face, err := ParseFace(faceName)
+	if err != nil {
+		return nil, &ParseError{
+			Input:  representation,
+			Offset: offset + size,
+			Reason: fmt.Sprintf("unrecognized face %q", faceName),
+		}
 	}
-	resultCard := Card{
+	return &Card{
 		Face: face,
 		Suit: suit,
-	}
-	return &resultCard, nil
+	}, nil
 }
 
 func Random(random rand.Rand) (*Card, error) {
@@ -190,7 +313,7 @@ func Random(random rand.Rand) (*Card, error) {
 	return New(suit, face)
 }
 
-func SuitOfUnicodeSymbol(unicode string) (string, error) {
+func SuitOfUnicodeSymbol(unicode string) (Suit, error) {
 	switch unicode {
 	case SuitSpadesUnicode:
 		return SuitSpades, nil
@@ -201,6 +324,6 @@ func SuitOfUnicodeSymbol(unicode string) (string, error) {
 	case SuitDiamondsUnicode:
 		return SuitDiamonds, nil
 	default:
-		return "", errors.New("not implemented suit")
+		return 0, errors.New("not implemented suit")
 	}
 }
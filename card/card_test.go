@@ -5,7 +5,6 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"math/rand"
-	"strconv"
 	"testing"
 	"time"
 )
@@ -16,7 +15,7 @@ func Test_isValidSuit(t *testing.T) {
 	assert.True(t, isValidSuit(SuitClubs))
 	assert.True(t, isValidSuit(SuitHearts))
 
-	assert.False(t, isValidSuit("Invalid"))
+	assert.False(t, isValidSuit(Suit(0)))
 }
 
 func Test_isValidFace(t *testing.T) {
@@ -34,13 +33,13 @@ func Test_isValidFace(t *testing.T) {
 	assert.True(t, isValidFace(FaceKing))
 	assert.True(t, isValidFace(FaceAce))
 
-	assert.False(t, isValidFace("Invalid"))
+	assert.False(t, isValidFace(Face(0)))
 }
 
 func TestNew(t *testing.T) {
 	t.Run("valid creation of cards", func(t *testing.T) {
-		suits := []string{SuitDiamonds, SuitSpades, SuitClubs, SuitHearts}
-		faces := []string{Face2, Face3, Face4, Face5, Face6, Face7, Face8, Face9, Face10, FaceJack, FaceQueen, FaceKing, FaceAce}
+		suits := []Suit{SuitDiamonds, SuitSpades, SuitClubs, SuitHearts}
+		faces := []Face{Face2, Face3, Face4, Face5, Face6, Face7, Face8, Face9, Face10, FaceJack, FaceQueen, FaceKing, FaceAce}
 
 		for _, suit := range suits {
 			for _, face := range faces {
@@ -52,12 +51,12 @@ func TestNew(t *testing.T) {
 		}
 	})
 	t.Run("invalid face results in error", func(t *testing.T) {
-		c, err := New(SuitHearts, "invalid")
+		c, err := New(SuitHearts, Face(0))
 		require.Error(t, err)
 		assert.Nil(t, c)
 	})
 	t.Run("invalid suit results in error", func(t *testing.T) {
-		c, err := New("invalid", FaceAce)
+		c, err := New(Suit(0), FaceAce)
 		require.Error(t, err)
 		assert.Nil(t, c)
 	})
@@ -102,7 +101,7 @@ func TestCard_SuitUnicode(t *testing.T) {
 	})
 	t.Run("invalid suit", func(t *testing.T) {
 		c := Card{
-			Suit: "invalid",
+			Suit: Suit(0),
 			Face: FaceAce,
 		}
 		unicode, err := c.SuitUnicode()
@@ -155,7 +154,7 @@ func TestCard_ShortRepresentation(t *testing.T) {
 	})
 	t.Run("invalid suit", func(t *testing.T) {
 		c := Card{
-			Suit: "invalid",
+			Suit: Suit(0),
 			Face: FaceAce,
 		}
 		representation, err := c.ShortRepresentation()
@@ -167,7 +166,7 @@ func TestCard_ShortRepresentation(t *testing.T) {
 		t.Run("invalid suit", func(t *testing.T) {
 			c := Card{
 				Suit: SuitSpades,
-				Face: "invalid",
+				Face: Face(0),
 			}
 			representation, err := c.ShortRepresentation()
 			require.Error(t, err)
@@ -218,7 +217,7 @@ func TestCard_IsNumeric(t *testing.T) {
 		assert.False(t, Card{Face: FaceAce}.IsNumeric())
 	})
 	t.Run("invalid face is not numeric", func(t *testing.T) {
-		assert.False(t, Card{Face: "invalid"}.IsNumeric())
+		assert.False(t, Card{Face: Face(0)}.IsNumeric())
 	})
 }
 
@@ -315,14 +314,14 @@ func TestCard_StrictNumericValue(t *testing.T) {
 	})
 
 	t.Run("invalid card face produces 0, error", func(t *testing.T) {
-		c := Card{Face: "invalid", Suit: SuitDiamonds}
+		c := Card{Face: Face(0), Suit: SuitDiamonds}
 		nv, err := c.StrictNumericValue()
 		require.Error(t, err)
 		assert.Equal(t, 0, nv)
 	})
 
 	t.Run("invalid numeric card face produces 0, error", func(t *testing.T) {
-		c := Card{Face: "1", Suit: SuitDiamonds}
+		c := Card{Face: Face(1), Suit: SuitDiamonds}
 		nv, err := c.StrictNumericValue()
 		require.Error(t, err)
 		assert.Equal(t, 0, nv)
@@ -333,7 +332,7 @@ func TestCard_StrictNumericValue(t *testing.T) {
 func TestCard_NumericValue(t *testing.T) {
 	t.Run("Numeric -> StrictNumeric", func(t *testing.T) {
 		for i := 2; i <= 10; i++ {
-			c := Card{Face: strconv.Itoa(i), Suit: SuitDiamonds}
+			c := Card{Face: Face(i), Suit: SuitDiamonds}
 			nv, err := c.StrictNumericValue()
 			require.NoError(t, err)
 			assert.Equal(t, i, c.NumericValue())
@@ -357,7 +356,7 @@ func TestCard_NumericValue(t *testing.T) {
 		assert.Equal(t, 14, c.NumericValue())
 	})
 	t.Run("invalid -> 0", func(t *testing.T) {
-		c := Card{Face: "invalid", Suit: SuitDiamonds}
+		c := Card{Face: Face(0), Suit: SuitDiamonds}
 		assert.Equal(t, 0, c.NumericValue())
 	})
 }
@@ -400,5 +399,118 @@ func TestFromShortRepresentation(t *testing.T) {
 		assert.Equal(t, FaceQueen, card.Face)
 		assert.Equal(t, SuitSpades, card.Suit)
 	})
+	t.Run("♠Q with CRLF and spaces", func(t *testing.T) {
+		representation := " \t♠Q \r\n"
+		card, err := FromShortRepresentation(representation)
+		require.NoError(t, err)
+		assert.Equal(t, FaceQueen, card.Face)
+		assert.Equal(t, SuitSpades, card.Suit)
+	})
+	t.Run("invalid face", func(t *testing.T) {
+		card, err := FromShortRepresentation(" ♠Z")
+		require.Error(t, err)
+		assert.Nil(t, card)
+		var parseError *ParseError
+		require.ErrorAs(t, err, &parseError)
+		assert.Equal(t, " ♠Z", parseError.Input)
+		assert.Equal(t, 4, parseError.Offset)
+		assert.Equal(t, `unrecognized face "Z"`, parseError.Reason)
+	})
+	t.Run("missing face", func(t *testing.T) {
+		_, err := FromShortRepresentation("♠")
+		var parseError *ParseError
+		require.ErrorAs(t, err, &parseError)
+		assert.Equal(t, 3, parseError.Offset)
+		assert.Equal(t, "missing face", parseError.Reason)
+	})
+	t.Run("invalid suit", func(t *testing.T) {
+		_, err := FromShortRepresentation("XA")
+		var parseError *ParseError
+		require.ErrorAs(t, err, &parseError)
+		assert.Equal(t, 0, parseError.Offset)
+		assert.Equal(t, `unrecognized suit 'X'`, parseError.Reason)
+	})
+	t.Run("empty", func(t *testing.T) {
+		_, err := FromShortRepresentation("\r\n")
+		var parseError *ParseError
+		require.ErrorAs(t, err, &parseError)
+		assert.Equal(t, "empty card", parseError.Reason)
+		assert.Equal(t, `cannot parse card "\r\n" at offset 2: empty card`, err.Error())
+	})
+	t.Run("joker is not a face", func(t *testing.T) {
+		for _, representation := range []string{"♠Joker", "Joker"} {
+			card, err := FromShortRepresentation(representation)
+			assert.Nil(t, card)
+			var parseError *ParseError
+			require.ErrorAs(t, err, &parseError, representation)
+		}
+	})
+
+}
+
+func TestSuit_String(t *testing.T) {
+	assert.Equal(t, "spades", SuitSpades.String())
+	assert.Equal(t, "diamonds", SuitDiamonds.String())
+	assert.Equal(t, "Suit(42)", Suit(42).String())
+}
+
+func TestParseSuit(t *testing.T) {
+	for _, suit := range Suits() {
+		parsed, err := ParseSuit(suit.String())
+		require.NoError(t, err)
+		assert.Equal(t, suit, parsed)
+	}
+	_, err := ParseSuit("invalid")
+	require.Error(t, err)
+}
+
+func TestFace_String(t *testing.T) {
+	assert.Equal(t, "10", Face10.String())
+	assert.Equal(t, "A", FaceAce.String())
+	assert.Equal(t, "Face(1)", Face(1).String())
+}
+
+func TestParseFace(t *testing.T) {
+	for _, face := range Faces() {
+		parsed, err := ParseFace(face.String())
+		require.NoError(t, err)
+		assert.Equal(t, face, parsed)
+	}
+	_, err := ParseFace("Z")
+	require.Error(t, err)
+	_, err = ParseFace("")
+	require.Error(t, err)
+	_, err = ParseFace("Joker")
+	require.Error(t, err)
+}
+
+func TestFaces(t *testing.T) {
+	faces := Faces()
+	assert.Equal(t, FaceCount, len(faces))
+	for i, face := range faces {
+		assert.Equal(t, i+2, int(face))
+	}
+	assert.Equal(t, SuitCount, len(Suits()))
+}
 
+func TestCard_Byte(t *testing.T) {
+	t.Run("round trip of every card", func(t *testing.T) {
+		seen := map[byte]bool{}
+		for _, suit := range Suits() {
+			for _, face := range Faces() {
+				c := Card{Suit: suit, Face: face}
+				b := c.Byte()
+				assert.False(t, seen[b])
+				seen[b] = true
+				restored, err := FromByte(b)
+				require.NoError(t, err)
+				assert.Equal(t, c, *restored)
+			}
+		}
+	})
+	t.Run("invalid byte", func(t *testing.T) {
+		c, err := FromByte(0)
+		require.Error(t, err)
+		assert.Nil(t, c)
+	})
 }
@@ -11,6 +11,7 @@ import (
 
 const ValidCombinationSize = 5
 
+const CombinationHighCard = "High Card"
 const CombinationPairName = "Pair"
 const CombinationTwoPairs = "Two Pairs"
 const CombinationThreeOfAKind = "Three Of A Kind"
@@ -19,26 +20,117 @@ const CombinationFlush = "Flush"
 const CombinationFullHouse = "Full House"
 const CombinationFourOfAKind = "Four Of A Kind"
 const CombinationStraightFlush = "Straight Flush"
+const CombinationRoyalFlush = "Royal Flush"
+const CombinationFiveOfAKind = "Five Of A Kind"
+
+const (
+	RankHighCard = iota + 1
+	RankPair
+	RankTwoPairs
+	RankThreeOfAKind
+	RankStraight
+	RankFlush
+	RankFullHouse
+	RankFourOfAKind
+	RankStraightFlush
+	// RankRoyalFlush is the ace-high straight flush, see IsSubtypeOf
+	RankRoyalFlush
+	// RankFiveOfAKind is only made with wild cards, see CombinationWithWilds
+	RankFiveOfAKind
+)
+
+// kickerBits is the width of a single kicker inside Strength
+const kickerBits = 4
+
+var combinationNames = map[int]string{
+	RankHighCard:      CombinationHighCard,
+	RankPair:          CombinationPairName,
+	RankTwoPairs:      CombinationTwoPairs,
+	RankThreeOfAKind:  CombinationThreeOfAKind,
+	RankStraight:      CombinationStraight,
+	RankFlush:         CombinationFlush,
+	RankFullHouse:     CombinationFullHouse,
+	RankFourOfAKind:   CombinationFourOfAKind,
+	RankStraightFlush: CombinationStraightFlush,
+	RankRoyalFlush:    CombinationRoyalFlush,
+	RankFiveOfAKind:   CombinationFiveOfAKind,
+}
+
+// Ranks returns every combination rank from the weakest to the strongest
+func Ranks() []int {
+	ranks := lo.Keys[int, string](combinationNames)
+	sort.Ints(ranks)
+	return ranks
+}
+
+// RankOf returns the rank of the combination with the given name, ignoring case
+func RankOf(name string) (int, error) {
+	for rank, combinationName := range combinationNames {
+		if strings.EqualFold(combinationName, name) {
+			return rank, nil
+		}
+	}
+	return 0, errors.New(fmt.Sprintf("unrecognized combination %s", name))
+}
+
+// IsSubtypeOf reports whether combinations of the rank are also combinations of the category,
+// e.g. every Royal Flush is a Straight Flush. Subtypes are stronger than the rest of their category,
+// so ordering by Strength is the same as if they were not told apart
+func IsSubtypeOf(rank int, category int) bool {
+	return rank == category || rank == RankRoyalFlush && category == RankStraightFlush
+}
+
+// NameOf returns the name of the combination with the given rank, empty for unknown ranks
+func NameOf(rank int) string {
+	return combinationNames[rank]
+}
 
 type PokerCombination interface {
 	Name() string
 	Cards() []Card
 	Representation() (string, error)
+	// Rank is the strength of the combination category, higher is better
+	Rank() int
+	// Kickers are the face values used to break ties within the same Rank,
+	// most significant first
+	Kickers() []int
+	// Strength packs Rank and Kickers into a single comparable number
+	Strength() int
 }
 
 type BasicPokerCombination struct {
-	name  string
-	cards []Card
+	strength int
+	cards    []Card
 }
 
 func (r BasicPokerCombination) Name() string {
-	return r.name
+	return NameOf(r.Rank())
 }
 
 func (r BasicPokerCombination) Cards() []Card {
 	return r.cards
 }
 
+func (r BasicPokerCombination) Rank() int {
+	return r.strength >> (kickerBits * ValidCombinationSize)
+}
+
+func (r BasicPokerCombination) Kickers() []int {
+	var kickers []int
+	for i := ValidCombinationSize - 1; i >= 0; i-- {
+		kicker := (r.strength >> (kickerBits * i)) & (1<<kickerBits - 1)
+		if kicker == 0 {
+			break
+		}
+		kickers = append(kickers, kicker)
+	}
+	return kickers
+}
+
+func (r BasicPokerCombination) Strength() int {
+	return r.strength
+}
+
 func (r BasicPokerCombination) Representation() (string, error) {
 	var builder strings.Builder
 	for index, card := range r.Cards() {
@@ -55,8 +147,8 @@ func (r BasicPokerCombination) Representation() (string, error) {
 	return builder.String(), nil
 }
 
-func countFaces(cards []Card) map[string]int {
-	cardFaceCount := map[string]int{}
+func countFaces(cards []Card) map[Face]int {
+	cardFaceCount := map[Face]int{}
 	for _, card := range cards {
 		if count, ok := cardFaceCount[card.Face]; ok {
 			cardFaceCount[card.Face] = count + 1
@@ -67,8 +159,8 @@ func countFaces(cards []Card) map[string]int {
 	return cardFaceCount
 }
 
-func countSuits(cards []Card) map[string]int {
-	cardSuitsCount := map[string]int{}
+func countSuits(cards []Card) map[Suit]int {
+	cardSuitsCount := map[Suit]int{}
 	for _, card := range cards {
 		if count, ok := cardSuitsCount[card.Suit]; ok {
 			cardSuitsCount[card.Suit] = count + 1
@@ -84,7 +176,7 @@ func isFaceBasedCombination(cards []Card, condition func([]int) bool) bool {
 		return false
 	}
 	cardFaceCount := countFaces(cards)
-	values := lo.Values[string, int](cardFaceCount)
+	values := lo.Values[Face, int](cardFaceCount)
 	sort.Slice(values, func(i, j int) bool {
 		return values[i] > values[j]
 	})
@@ -109,6 +201,12 @@ func isCombinationOfFourOfAKind(cards []Card) bool {
 	})
 }
 
+func isCombinationOfFiveOfAKind(cards []Card) bool {
+	return isFaceBasedCombination(cards, func(values []int) bool {
+		return values[0] == 5
+	})
+}
+
 func isCombinationOfFullHouse(cards []Card) bool {
 	return isFaceBasedCombination(cards, func(values []int) bool {
 		return values[0] == 3 && values[1] == 2
@@ -149,6 +247,14 @@ func isCombinationOfStraight(cards []Card) bool {
 	}
 }
 
+func isCombinationOfRoyalFlush(cards []Card) bool {
+	if !isCombinationOfFlush(cards) || !isCombinationOfStraight(cards) {
+		return false
+	}
+	faces := countFaces(cards)
+	return faces[FaceAce] == 1 && faces[FaceKing] == 1
+}
+
 func isCombinationOfFlush(cards []Card) bool {
 	if len(cards) != ValidCombinationSize {
 		return false
@@ -158,28 +264,130 @@ func isCombinationOfFlush(cards []Card) bool {
 	return len(suitsCount) == 1 && suitsCount[0] == 5
 }
 
-func CombinationOf(cards []Card) (PokerCombination, error) {
-	if len(cards) != ValidCombinationSize {
-		return nil, errors.New("cards is not of valid size")
+// kickersOf orders face values by how often they occur and then by value,
+// so that [K, 5, K, 5, 9] gives [13, 5, 9]. Straights are only described by
+// their highest card, which is 5 for the ace-low wheel
+func kickersOf(cards []Card, straight bool) []int {
+	values := lo.Map[Card, int](cards, func(card Card, index int) int {
+		return card.NumericValue()
+	})
+	sort.Slice(values, func(i, j int) bool {
+		return values[i] > values[j]
+	})
+	if straight {
+		if values[0] == NumericValueAce && values[1] == 5 {
+			return []int{5}
+		}
+		return []int{values[0]}
+	}
+
+	counts := map[int]int{}
+	for _, value := range values {
+		counts[value]++
+	}
+	kickers := lo.Uniq[int](values)
+	sort.SliceStable(kickers, func(i, j int) bool {
+		return counts[kickers[i]] > counts[kickers[j]]
+	})
+	return kickers
+}
+
+func strengthOf(rank int, kickers []int) int {
+	strength := rank
+	for i := 0; i < ValidCombinationSize; i++ {
+		strength <<= kickerBits
+		if i < len(kickers) {
+			strength |= kickers[i]
+		}
 	}
+	return strength
+}
+
+// classify is the straightforward predicate-based classification.
+// It is too slow to be called for every hand, so it is only used to fill the
+// lookup tables of Evaluate
+func classify(cards []Card) int {
+	var rank int
 	switch {
+	case isCombinationOfFiveOfAKind(cards):
+		rank = RankFiveOfAKind
+	case isCombinationOfRoyalFlush(cards):
+		rank = RankRoyalFlush
 	case isCombinationOfFlush(cards) && isCombinationOfStraight(cards):
-		return BasicPokerCombination{name: CombinationStraightFlush, cards: cards}, nil
+		rank = RankStraightFlush
 	case isCombinationOfFourOfAKind(cards):
-		return BasicPokerCombination{name: CombinationFourOfAKind, cards: cards}, nil
+		rank = RankFourOfAKind
 	case isCombinationOfFullHouse(cards):
-		return BasicPokerCombination{name: CombinationFullHouse, cards: cards}, nil
+		rank = RankFullHouse
 	case isCombinationOfFlush(cards):
-		return BasicPokerCombination{name: CombinationFlush, cards: cards}, nil
+		rank = RankFlush
 	case isCombinationOfStraight(cards):
-		return BasicPokerCombination{name: CombinationStraight, cards: cards}, nil
+		rank = RankStraight
 	case isCombinationOfThreeOfAKind(cards):
-		return BasicPokerCombination{name: CombinationThreeOfAKind, cards: cards}, nil
+		rank = RankThreeOfAKind
 	case isCombinationOfTwoPairs(cards):
-		return BasicPokerCombination{name: CombinationTwoPairs, cards: cards}, nil
+		rank = RankTwoPairs
 	case isCombinationOfPair(cards):
-		return BasicPokerCombination{name: CombinationPairName, cards: cards}, nil
+		rank = RankPair
+	default:
+		rank = RankHighCard
+	}
+	straight := rank == RankStraight || IsSubtypeOf(rank, RankStraightFlush)
+	return strengthOf(rank, kickersOf(cards, straight))
+}
+
+// CombinationOf returns the combination of the cards, jokers are substituted like in CombinationWithWilds
+func CombinationOf(cards []Card) (PokerCombination, error) {
+	if len(cards) != ValidCombinationSize {
+		return nil, errors.New("cards is not of valid size")
+	}
+	if slices.Contains(cards, Joker) {
+		return CombinationWithWilds(cards, nil)
+	}
+	var encoded [ValidCombinationSize]EncodedCard
+	for i, card := range cards {
+		encodedCard, err := card.Encode()
+		if err != nil {
+			return nil, err
+		}
+		encoded[i] = encodedCard
+	}
+	strength := Evaluate(encoded[0], encoded[1], encoded[2], encoded[3], encoded[4])
+	if strength == 0 {
+		// repeated cards are not in the tables
+		strength = classify(cards)
+	}
+	return BasicPokerCombination{strength: strength, cards: cards}, nil
+}
+
+// Detach returns the combination with its own copy of the cards, so that it stays valid after
+// the cards it was made of are reused, e.g. by combinatorics.EachCombination.
+// Only combinations of this package can be detached
+func Detach(combination PokerCombination) (PokerCombination, error) {
+	basic, ok := combination.(BasicPokerCombination)
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("cannot detach combination of type %T", combination))
+	}
+	basic.cards = append([]Card(nil), basic.cards...)
+	return basic, nil
+}
+
+// Compare returns -1 if a is weaker than b, 1 if a is stronger and 0 if they tie.
+// A nil combination is weaker than any other one
+func Compare(a, b PokerCombination) int {
+	strengthOf := func(combination PokerCombination) int {
+		if combination == nil {
+			return -1
+		}
+		return combination.Strength()
+	}
+	strengthA, strengthB := strengthOf(a), strengthOf(b)
+	switch {
+	case strengthA < strengthB:
+		return -1
+	case strengthA > strengthB:
+		return 1
 	default:
-		return nil, nil
+		return 0
 	}
 }
@@ -665,7 +665,165 @@ func TestBasicPokerCombination_Representation(t *testing.T) {
 			},
 		}
 		combination, err := CombinationOf(cards)
-		require.Nil(t, combination)
+		require.NotNil(t, combination)
+		require.NoError(t, err)
+		representation, err := combination.Representation()
+		require.NoError(t, err)
+		assert.Equal(t, "♠3,♠5,♠A,♠10,♦K | High Card", representation)
+	})
+}
+
+func cardsOf(t *testing.T, representations ...string) []Card {
+	t.Helper()
+	cards := make([]Card, 0, len(representations))
+	for _, representation := range representations {
+		card, err := FromShortRepresentation(representation)
 		require.NoError(t, err)
+		cards = append(cards, *card)
+	}
+	return cards
+}
+
+func combinationOf(t *testing.T, representations ...string) PokerCombination {
+	t.Helper()
+	combination, err := CombinationOf(cardsOf(t, representations...))
+	require.NoError(t, err)
+	require.NotNil(t, combination)
+	return combination
+}
+
+func TestBasicPokerCombination_Kickers(t *testing.T) {
+	t.Run("pair is followed by remaining cards in descending order", func(t *testing.T) {
+		combination := combinationOf(t, "♠2", "♠5", "♠A", "♠K", "♦K")
+		assert.Equal(t, RankPair, combination.Rank())
+		assert.Equal(t, []int{13, 14, 5, 2}, combination.Kickers())
+	})
+	t.Run("two pairs put higher pair first", func(t *testing.T) {
+		combination := combinationOf(t, "♠5", "♦5", "♠9", "♠K", "♦K")
+		assert.Equal(t, RankTwoPairs, combination.Rank())
+		assert.Equal(t, []int{13, 5, 9}, combination.Kickers())
+	})
+	t.Run("full house puts three of a kind first", func(t *testing.T) {
+		combination := combinationOf(t, "♠5", "♦5", "♥5", "♠K", "♦K")
+		assert.Equal(t, RankFullHouse, combination.Rank())
+		assert.Equal(t, []int{5, 13}, combination.Kickers())
+	})
+	t.Run("wheel straight is five high", func(t *testing.T) {
+		combination := combinationOf(t, "♠A", "♦2", "♥3", "♠4", "♦5")
+		assert.Equal(t, RankStraight, combination.Rank())
+		assert.Equal(t, []int{5}, combination.Kickers())
+	})
+	t.Run("high card lists every card in descending order", func(t *testing.T) {
+		combination := combinationOf(t, "♠3", "♠5", "♠A", "♠10", "♦K")
+		assert.Equal(t, RankHighCard, combination.Rank())
+		assert.Equal(t, CombinationHighCard, combination.Name())
+		assert.Equal(t, []int{14, 13, 10, 5, 3}, combination.Kickers())
+	})
+	t.Run("broadway straight flush is a royal flush", func(t *testing.T) {
+		combination := combinationOf(t, "♠A", "♠K", "♠Q", "♠J", "♠10")
+		assert.Equal(t, RankRoyalFlush, combination.Rank())
+		assert.Equal(t, CombinationRoyalFlush, combination.Name())
+		assert.Equal(t, []int{14}, combination.Kickers())
+	})
+	t.Run("king high straight flush is not royal", func(t *testing.T) {
+		combination := combinationOf(t, "♠9", "♠K", "♠Q", "♠J", "♠10")
+		assert.Equal(t, RankStraightFlush, combination.Rank())
+		assert.Equal(t, []int{13}, combination.Kickers())
+	})
+}
+
+func TestDetach(t *testing.T) {
+	cards := cardsOf(t, "♠5", "♦5", "♥5", "♠K", "♦K")
+	combination, err := CombinationOf(cards)
+	require.NoError(t, err)
+	detached, err := Detach(combination)
+	require.NoError(t, err)
+
+	cards[0], cards[3] = cards[3], cards[0]
+	assert.Equal(t, combination.Strength(), detached.Strength())
+	assert.Equal(t, cardsOf(t, "♠5", "♦5", "♥5", "♠K", "♦K"), detached.Cards())
+	assert.Equal(t, cards, combination.Cards())
+
+	_, err = Detach(nil)
+	require.Error(t, err)
+}
+
+func TestCompare(t *testing.T) {
+	t.Run("higher category wins", func(t *testing.T) {
+		flush := combinationOf(t, "♠2", "♠5", "♠9", "♠J", "♠K")
+		straight := combinationOf(t, "♠10", "♦J", "♥Q", "♠K", "♦A")
+		assert.Equal(t, 1, Compare(flush, straight))
+		assert.Equal(t, -1, Compare(straight, flush))
+	})
+	t.Run("higher pair wins", func(t *testing.T) {
+		kings := combinationOf(t, "♠2", "♠5", "♠7", "♠K", "♦K")
+		queens := combinationOf(t, "♠A", "♦J", "♥9", "♠Q", "♦Q")
+		assert.Equal(t, 1, Compare(kings, queens))
+	})
+	t.Run("same pair is decided by kickers", func(t *testing.T) {
+		aceKicker := combinationOf(t, "♠2", "♠5", "♠A", "♠K", "♦K")
+		queenKicker := combinationOf(t, "♥2", "♥5", "♥Q", "♥K", "♣K")
+		assert.Equal(t, 1, Compare(aceKicker, queenKicker))
+	})
+	t.Run("two pairs are decided by lower pair before kicker", func(t *testing.T) {
+		kingsAndSixes := combinationOf(t, "♠6", "♦6", "♠2", "♠K", "♦K")
+		kingsAndFives := combinationOf(t, "♥5", "♣5", "♥A", "♥K", "♣K")
+		assert.Equal(t, 1, Compare(kingsAndSixes, kingsAndFives))
+	})
+	t.Run("wheel is the lowest straight", func(t *testing.T) {
+		wheel := combinationOf(t, "♠A", "♦2", "♥3", "♠4", "♦5")
+		sixHigh := combinationOf(t, "♠2", "♦3", "♥4", "♠5", "♦6")
+		assert.Equal(t, -1, Compare(wheel, sixHigh))
+	})
+	t.Run("same hand in different suits ties", func(t *testing.T) {
+		spades := combinationOf(t, "♠2", "♠5", "♠9", "♠J", "♠K")
+		hearts := combinationOf(t, "♥2", "♥5", "♥9", "♥J", "♥K")
+		assert.Equal(t, 0, Compare(spades, hearts))
+	})
+	t.Run("high card is weaker than a pair", func(t *testing.T) {
+		highCard := combinationOf(t, "♠3", "♠5", "♠A", "♠10", "♦K")
+		pair := combinationOf(t, "♠2", "♦2", "♠4", "♠6", "♦7")
+		assert.Equal(t, -1, Compare(highCard, pair))
 	})
+	t.Run("royal flush is the strongest straight flush", func(t *testing.T) {
+		royal := combinationOf(t, "♥A", "♥K", "♥Q", "♥J", "♥10")
+		kingHigh := combinationOf(t, "♠9", "♠K", "♠Q", "♠J", "♠10")
+		fourAces := combinationOf(t, "♠A", "♦A", "♥A", "♣A", "♦K")
+		assert.Equal(t, 1, Compare(royal, kingHigh))
+		assert.Equal(t, 1, Compare(kingHigh, fourAces))
+		assert.Equal(t, 0, Compare(royal, combinationOf(t, "♠A", "♠K", "♠Q", "♠J", "♠10")))
+	})
+	t.Run("nil is weaker than any combination", func(t *testing.T) {
+		pair := combinationOf(t, "♠2", "♠5", "♠A", "♠K", "♦K")
+		assert.Equal(t, -1, Compare(nil, pair))
+		assert.Equal(t, 1, Compare(pair, nil))
+		assert.Equal(t, 0, Compare(nil, nil))
+	})
+}
+
+func TestRankOf(t *testing.T) {
+	rank, err := RankOf(CombinationTwoPairs)
+	require.NoError(t, err)
+	assert.Equal(t, RankTwoPairs, rank)
+
+	rank, err = RankOf("straight flush")
+	require.NoError(t, err)
+	assert.Equal(t, RankStraightFlush, rank)
+
+	_, err = RankOf("Royal Pair")
+	require.Error(t, err)
+}
+
+func TestRanks(t *testing.T) {
+	ranks := Ranks()
+	assert.Equal(t, RankHighCard, ranks[0])
+	assert.Equal(t, RankFiveOfAKind, ranks[len(ranks)-1])
+	assert.Equal(t, len(combinationNames), len(ranks))
+}
+
+func TestIsSubtypeOf(t *testing.T) {
+	assert.True(t, IsSubtypeOf(RankRoyalFlush, RankStraightFlush))
+	assert.True(t, IsSubtypeOf(RankFlush, RankFlush))
+	assert.False(t, IsSubtypeOf(RankStraightFlush, RankRoyalFlush))
+	assert.False(t, IsSubtypeOf(RankStraightFlush, RankStraight))
 }
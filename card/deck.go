@@ -0,0 +1,95 @@
+package card
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+)
+
+const StandardDeckSize = SuitCount * FaceCount
+
+// Deck is an ordered pile of cards that are dealt from the top.
+// Dealt, burned and removed cards never come back
+type Deck struct {
+	cards []Card
+}
+
+// NewDeck returns a standard 52-card deck in a fixed order
+func NewDeck() *Deck {
+	return NewShoe(1, 0)
+}
+
+// NewShoe returns decks standard decks with jokers jokers added to each of them, in a fixed order
+func NewShoe(decks int, jokers int) *Deck {
+	cards := make([]Card, 0, decks*(StandardDeckSize+jokers))
+	for i := 0; i < decks; i++ {
+		for _, suit := range Suits() {
+			for _, face := range Faces() {
+				cards = append(cards, Card{Suit: suit, Face: face})
+			}
+		}
+		for j := 0; j < jokers; j++ {
+			cards = append(cards, Joker)
+		}
+	}
+	return &Deck{cards: cards}
+}
+
+// Shuffle reorders the remaining cards with the Fisher–Yates algorithm,
+// so the same random source always produces the same order
+func (d *Deck) Shuffle(random *rand.Rand) {
+	for i := len(d.cards) - 1; i > 0; i-- {
+		j := random.Intn(i + 1)
+		d.cards[i], d.cards[j] = d.cards[j], d.cards[i]
+	}
+}
+
+// Deal takes n cards from the top of the deck
+func (d *Deck) Deal(n int) ([]Card, error) {
+	if n < 0 || n > len(d.cards) {
+		return nil, errors.New(fmt.Sprintf("cannot deal %d cards, %d remaining", n, len(d.cards)))
+	}
+	dealt := make([]Card, n)
+	copy(dealt, d.cards[:n])
+	d.cards = d.cards[n:]
+	return dealt, nil
+}
+
+// Burn discards the top card of the deck
+func (d *Deck) Burn() error {
+	_, err := d.Deal(1)
+	return err
+}
+
+// Remaining is the count of cards that can still be dealt
+func (d *Deck) Remaining() int {
+	return len(d.cards)
+}
+
+// Cards returns the remaining cards from the top to the bottom
+func (d *Deck) Cards() []Card {
+	cards := make([]Card, len(d.cards))
+	copy(cards, d.cards)
+	return cards
+}
+
+// Remove takes known cards, e.g. cards already held by players, out of the deck.
+// Every card removes a single copy, and nothing is removed if any card is missing
+func (d *Deck) Remove(cards ...Card) error {
+	remaining := d.Cards()
+	for _, card := range cards {
+		index := -1
+		for i, candidate := range remaining {
+			if candidate == card {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return errors.New(fmt.Sprintf("card %s is not in the deck", card))
+		}
+		remaining = append(remaining[:index], remaining[index+1:]...)
+	}
+	d.cards = remaining
+	return nil
+}
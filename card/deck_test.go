@@ -0,0 +1,105 @@
+package card
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math/rand"
+	"testing"
+)
+
+func TestNewDeck(t *testing.T) {
+	deck := NewDeck()
+	assert.Equal(t, StandardDeckSize, deck.Remaining())
+	assert.Equal(t, StandardDeckSize, len(uniqueCards(deck.Cards())))
+}
+
+func TestNewShoe(t *testing.T) {
+	shoe := NewShoe(2, 2)
+	cards := shoe.Cards()
+	assert.Equal(t, 2*(StandardDeckSize+2), shoe.Remaining())
+	assert.Equal(t, StandardDeckSize+1, len(uniqueCards(cards)))
+
+	jokers := 0
+	for _, c := range cards {
+		if c.IsJoker() {
+			jokers++
+		}
+	}
+	assert.Equal(t, 4, jokers)
+}
+
+func TestDeck_Shuffle(t *testing.T) {
+	t.Run("same seed produces same order", func(t *testing.T) {
+		first, second := NewDeck(), NewDeck()
+		first.Shuffle(rand.New(rand.NewSource(42)))
+		second.Shuffle(rand.New(rand.NewSource(42)))
+		assert.Equal(t, first.Cards(), second.Cards())
+		assert.NotEqual(t, NewDeck().Cards(), first.Cards())
+	})
+	t.Run("shuffle keeps every card", func(t *testing.T) {
+		deck := NewDeck()
+		deck.Shuffle(rand.New(rand.NewSource(1)))
+		assert.ElementsMatch(t, NewDeck().Cards(), deck.Cards())
+	})
+}
+
+func TestDeck_Deal(t *testing.T) {
+	t.Run("deals from the top without duplicates", func(t *testing.T) {
+		deck := NewDeck()
+		deck.Shuffle(rand.New(rand.NewSource(7)))
+		top := deck.Cards()[:5]
+
+		dealt, err := deck.Deal(5)
+		require.NoError(t, err)
+		assert.Equal(t, top, dealt)
+		assert.Equal(t, StandardDeckSize-5, deck.Remaining())
+		for _, c := range dealt {
+			assert.NotContains(t, deck.Cards(), c)
+		}
+	})
+	t.Run("cannot deal more than remaining", func(t *testing.T) {
+		deck := NewDeck()
+		_, err := deck.Deal(StandardDeckSize + 1)
+		require.Error(t, err)
+		assert.Equal(t, StandardDeckSize, deck.Remaining())
+	})
+	t.Run("burn discards a card", func(t *testing.T) {
+		deck := NewDeck()
+		second := deck.Cards()[1]
+		require.NoError(t, deck.Burn())
+		dealt, err := deck.Deal(1)
+		require.NoError(t, err)
+		assert.Equal(t, []Card{second}, dealt)
+	})
+	t.Run("burn of empty deck", func(t *testing.T) {
+		deck := NewDeck()
+		_, err := deck.Deal(StandardDeckSize)
+		require.NoError(t, err)
+		require.Error(t, deck.Burn())
+	})
+}
+
+func TestDeck_Remove(t *testing.T) {
+	t.Run("removes known cards", func(t *testing.T) {
+		deck := NewDeck()
+		known := cardsOf(t, "♠A", "♥K")
+		require.NoError(t, deck.Remove(known...))
+		assert.Equal(t, StandardDeckSize-2, deck.Remaining())
+		assert.NotContains(t, deck.Cards(), known[0])
+		assert.NotContains(t, deck.Cards(), known[1])
+	})
+	t.Run("missing card removes nothing", func(t *testing.T) {
+		deck := NewDeck()
+		err := deck.Remove(cardsOf(t, "♠A", "♠A")...)
+		require.Error(t, err)
+		assert.Equal(t, StandardDeckSize, deck.Remaining())
+	})
+}
+
+func uniqueCards(cards []Card) map[Card]bool {
+	unique := map[Card]bool{}
+	for _, c := range cards {
+		unique[c] = true
+	}
+	return unique
+}
@@ -0,0 +1,128 @@
+package card
+
+import (
+	"errors"
+	"fmt"
+)
+
+// EncodedCard is a bit-packed card suitable for table lookups:
+//
+//	+--------+--------+--------+--------+
+//	|xxxbbbbb|bbbbbbbb|cdhsrrrr|xxpppppp|
+//	+--------+--------+--------+--------+
+//
+// b is a bit set for the face (bit 16 for 2 up to bit 28 for ace),
+// cdhs is the suit, r is the face index (0 for 2 up to 12 for ace)
+// and p is the prime number of the face
+type EncodedCard uint32
+
+const (
+	encodedSuitClubs    EncodedCard = 0x8000
+	encodedSuitDiamonds EncodedCard = 0x4000
+	encodedSuitHearts   EncodedCard = 0x2000
+	encodedSuitSpades   EncodedCard = 0x1000
+
+	encodedSuitMask  EncodedCard = 0xF000
+	encodedPrimeMask EncodedCard = 0x3F
+	encodedFaceShift             = 16
+)
+
+// facePrimes are chosen so that the product of primes of five faces is unique
+// for every multiset of faces
+var facePrimes = [FaceCount]EncodedCard{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41}
+
+var (
+	// flushTable and uniqueTable are indexed by the face bits of a hand with five distinct faces
+	flushTable  [1 << FaceCount]int
+	uniqueTable [1 << FaceCount]int
+	// pairedTable is keyed by the product of face primes of a hand with repeated faces
+	pairedTable = map[EncodedCard]int{}
+)
+
+func init() {
+	suits := Suits()
+	faces := Faces()
+
+	var fill func(face int, counts []int, left int)
+	fill = func(face int, counts []int, left int) {
+		if left == 0 {
+			cards := make([]Card, 0, ValidCombinationSize)
+			bits, product := 0, EncodedCard(1)
+			for index, count := range counts {
+				for i := 0; i < count; i++ {
+					cards = append(cards, Card{Face: faces[index], Suit: suits[i]})
+					product *= facePrimes[index]
+				}
+				if count > 0 {
+					bits |= 1 << index
+				}
+			}
+			if countOnes(bits) == ValidCombinationSize {
+				// distinct faces: spread suits so the hand is not a flush
+				cards[ValidCombinationSize-1].Suit = suits[1]
+				uniqueTable[bits] = classify(cards)
+				for i := range cards {
+					cards[i].Suit = suits[0]
+				}
+				flushTable[bits] = classify(cards)
+			} else {
+				pairedTable[product] = classify(cards)
+			}
+			return
+		}
+		if face == FaceCount {
+			return
+		}
+		for count := 0; count <= SuitCount && count <= left; count++ {
+			counts[face] = count
+			fill(face+1, counts, left-count)
+		}
+		counts[face] = 0
+	}
+	fill(0, make([]int, FaceCount), ValidCombinationSize)
+}
+
+func countOnes(bits int) int {
+	count := 0
+	for ; bits != 0; bits &= bits - 1 {
+		count++
+	}
+	return count
+}
+
+// Encode packs the card into an EncodedCard
+func (c Card) Encode() (EncodedCard, error) {
+	var suit EncodedCard
+	switch c.Suit {
+	case SuitClubs:
+		suit = encodedSuitClubs
+	case SuitDiamonds:
+		suit = encodedSuitDiamonds
+	case SuitHearts:
+		suit = encodedSuitHearts
+	case SuitSpades:
+		suit = encodedSuitSpades
+	default:
+		return 0, errors.New(fmt.Sprintf("unrecognized suit %s", c.Suit))
+	}
+	if !isValidFace(c.Face) {
+		return 0, errors.New(fmt.Sprintf("unrecognized face %s", c.Face))
+	}
+	face := EncodedCard(c.Face - Face2)
+	return 1<<(encodedFaceShift+face) | suit | face<<8 | facePrimes[face], nil
+}
+
+// Evaluate returns the Strength of five distinct encoded cards without allocating.
+// Hands that cannot be dealt from a single deck, like a flush with a repeated card, evaluate to 0
+func Evaluate(c1, c2, c3, c4, c5 EncodedCard) int {
+	faceBits := (c1 | c2 | c3 | c4 | c5) >> encodedFaceShift
+	if c1&c2&c3&c4&c5&encodedSuitMask != 0 {
+		return flushTable[faceBits]
+	}
+	if strength := uniqueTable[faceBits]; strength != 0 {
+		return strength
+	}
+	product := (c1 & encodedPrimeMask) * (c2 & encodedPrimeMask) * (c3 & encodedPrimeMask) *
+		(c4 & encodedPrimeMask) * (c5 & encodedPrimeMask)
+	return pairedTable[product]
+}
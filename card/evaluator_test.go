@@ -0,0 +1,101 @@
+package card
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math/rand"
+	"testing"
+)
+
+func encodeAll(t testing.TB, cards []Card) []EncodedCard {
+	encoded := make([]EncodedCard, len(cards))
+	for i, card := range cards {
+		encodedCard, err := card.Encode()
+		require.NoError(t, err)
+		encoded[i] = encodedCard
+	}
+	return encoded
+}
+
+func fullDeck() []Card {
+	var deck []Card
+	for _, suit := range Suits() {
+		for _, face := range Faces() {
+			deck = append(deck, Card{Suit: suit, Face: face})
+		}
+	}
+	return deck
+}
+
+func TestCard_Encode(t *testing.T) {
+	t.Run("king of diamonds", func(t *testing.T) {
+		encoded, err := Card{Face: FaceKing, Suit: SuitDiamonds}.Encode()
+		require.NoError(t, err)
+		assert.Equal(t, EncodedCard(0x08004b25), encoded)
+	})
+	t.Run("five of spades", func(t *testing.T) {
+		encoded, err := Card{Face: Face5, Suit: SuitSpades}.Encode()
+		require.NoError(t, err)
+		assert.Equal(t, EncodedCard(0x00081307), encoded)
+	})
+	t.Run("invalid suit", func(t *testing.T) {
+		_, err := Card{Face: Face5, Suit: Suit(0)}.Encode()
+		require.Error(t, err)
+	})
+	t.Run("invalid face", func(t *testing.T) {
+		_, err := Card{Face: Face(0), Suit: SuitSpades}.Encode()
+		require.Error(t, err)
+	})
+}
+
+func TestEvaluate(t *testing.T) {
+	t.Run("tables contain every distinct hand", func(t *testing.T) {
+		distinct := map[int]bool{}
+		for bits := range uniqueTable {
+			if uniqueTable[bits] != 0 {
+				distinct[uniqueTable[bits]] = true
+				distinct[flushTable[bits]] = true
+			}
+		}
+		for _, strength := range pairedTable {
+			distinct[strength] = true
+		}
+		assert.Equal(t, 4888, len(pairedTable))
+		assert.Equal(t, 7462, len(distinct))
+	})
+	t.Run("agrees with predicates on random hands", func(t *testing.T) {
+		random := rand.New(rand.NewSource(1))
+		deck := fullDeck()
+		for i := 0; i < 20_000; i++ {
+			random.Shuffle(len(deck), func(i, j int) {
+				deck[i], deck[j] = deck[j], deck[i]
+			})
+			hand := deck[:ValidCombinationSize]
+			encoded := encodeAll(t, hand)
+			strength := Evaluate(encoded[0], encoded[1], encoded[2], encoded[3], encoded[4])
+			require.Equal(t, classify(hand), strength, "%v", hand)
+		}
+	})
+	t.Run("does not allocate", func(t *testing.T) {
+		encoded := encodeAll(t, cardsOf(t, "♠2", "♦2", "♠A", "♠K", "♦K"))
+		allocations := testing.AllocsPerRun(100, func() {
+			Evaluate(encoded[0], encoded[1], encoded[2], encoded[3], encoded[4])
+		})
+		assert.Equal(t, 0.0, allocations)
+	})
+}
+
+func TestCombinationOf_repeatedCards(t *testing.T) {
+	combination := combinationOf(t, "♠2", "♠2", "♠A", "♠K", "♠9")
+	assert.Equal(t, CombinationFlush, combination.Name())
+}
+
+func BenchmarkEvaluate(b *testing.B) {
+	deck := encodeAll(b, fullDeck())
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		j := i % (len(deck) - ValidCombinationSize)
+		Evaluate(deck[j], deck[j+1], deck[j+2], deck[j+3], deck[j+4])
+	}
+}
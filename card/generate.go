@@ -1,52 +1,40 @@
 package card
 
 import (
-	"encoding/csv"
-	"fmt"
-	"github.com/samber/lo"
-	"log"
 	"math/rand"
-	"os"
 )
 
-func cardsToRepresentations(cards []Card) []string {
-	representations := lo.Map[Card, string](cards, func(c Card, index int) string {
-		r, _ := c.ShortRepresentation()
-		return r
-	})
-	return representations
-}
-
-func main() {
-	var seed int64 = 1665694295623135151
-	randomSource := rand.NewSource(seed)
-	random := rand.New(randomSource)
-	log.Printf("Initialized random with seed %d\n", seed)
-
-	fmt.Println("Starting to generate cards...")
-	for i := 0; i < 100; i++ {
-		log.Printf("Iteration %d\n", i)
-		cardsInFile := random.Intn(7) + 10 // [10, 17]
-		cards := make([]Card, 0)
-
-		for j := 0; j < cardsInFile; j++ {
-			generatedCard, _ := Random(*random)
-			cards = append(cards, *generatedCard)
-		}
-		log.Printf("Generated cards %s\n", cards)
-		summary := cardsToRepresentations(cards)
-		file, err := os.Create(fmt.Sprintf("dataset/dat%d.csv", i))
-
+// FormatCards writes every card in the given notation
+func FormatCards(cards []Card, notation Notation) ([]string, error) {
+	representations := make([]string, 0, len(cards))
+	for _, c := range cards {
+		representation, err := c.Format(notation)
 		if err != nil {
-			log.Fatalln("failed to open file", err)
+			return nil, err
 		}
+		representations = append(representations, representation)
+	}
+	return representations, nil
+}
 
-		writer := csv.NewWriter(file)
-		if err = writer.Write(summary); err != nil {
-			log.Fatalln("error writing to a file!")
+// RandomCards draws count cards independently of each other,
+// so the same card can appear more than once
+func RandomCards(random *rand.Rand, count int) ([]Card, error) {
+	cards := make([]Card, 0, count)
+	for i := 0; i < count; i++ {
+		generatedCard, err := Random(*random)
+		if err != nil {
+			return nil, err
 		}
-
-		writer.Flush()
-		_ = file.Close()
+		cards = append(cards, *generatedCard)
 	}
+	return cards, nil
+}
+
+// DealtCards deals count cards from a freshly shuffled standard deck,
+// so every card appears at most once
+func DealtCards(random *rand.Rand, count int) ([]Card, error) {
+	deck := NewDeck()
+	deck.Shuffle(random)
+	return deck.Deal(count)
 }
@@ -3,10 +3,11 @@ package card
 import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"math/rand"
 	"testing"
 )
 
-func Test_cardsToRepresentation(t *testing.T) {
+func TestFormatCards(t *testing.T) {
 	t.Run("valid cards", func(t *testing.T) {
 		card1, err := New(SuitDiamonds, FaceAce)
 		require.NoError(t, err)
@@ -16,28 +17,49 @@ func Test_cardsToRepresentation(t *testing.T) {
 		require.NoError(t, err)
 
 		cards := []Card{*card1, *card2, *card3}
-		representations := cardsToRepresentations(cards)
+		representations, err := FormatCards(cards, NotationShort)
+		require.NoError(t, err)
 		assert.Equal(t, []string{"♦A", "♠J", "♠10"}, representations)
+
+		representations, err = FormatCards(cards, NotationASCII)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Ad", "Js", "Ts"}, representations)
 	})
 
-	t.Run("invalid cards produce empty representations", func(t *testing.T) {
+	t.Run("invalid cards produce error", func(t *testing.T) {
 		card1 := Card{
-			Face: "invalid",
+			Face: Face(0),
 			Suit: SuitSpades,
 		}
 
 		card2 := Card{
 			Face: FaceAce,
-			Suit: "invalid",
+			Suit: Suit(0),
 		}
 
-		card3 := Card{
-			Face: FaceKing,
-			Suit: "invalid",
+		for _, c := range []Card{card1, card2} {
+			representations, err := FormatCards([]Card{c}, NotationShort)
+			require.Error(t, err)
+			assert.Nil(t, representations)
 		}
-
-		cards := []Card{card1, card2, card3}
-		representations := cardsToRepresentations(cards)
-		assert.Equal(t, []string{"", "", ""}, representations)
 	})
 }
+
+func TestRandomCards(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	cards, err := RandomCards(random, 20)
+	require.NoError(t, err)
+	assert.Equal(t, 20, len(cards))
+	// Can be tested because of the fixed seed
+	assert.Equal(t, Card{Suit: SuitDiamonds, Face: Face10}, cards[0])
+}
+
+func TestDealtCards(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	cards, err := DealtCards(random, StandardDeckSize)
+	require.NoError(t, err)
+	assert.Equal(t, StandardDeckSize, len(uniqueCards(cards)))
+
+	_, err = DealtCards(random, StandardDeckSize+1)
+	require.Error(t, err)
+}
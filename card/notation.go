@@ -0,0 +1,332 @@
+package card
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Notation is a textual format of a single card
+type Notation int
+
+const (
+	// NotationAuto detects the notation while parsing, it cannot be used for formatting
+	NotationAuto Notation = iota
+	// NotationShort is the suit symbol followed by the face, e.g. "♠A" or "♦10"
+	NotationShort
+	// NotationASCII is the face letter followed by the suit letter, e.g. "As" or "Td"
+	NotationASCII
+	// NotationLong is the full name, e.g. "Ace of Spades"
+	NotationLong
+	// NotationUnicode is a codepoint of the Unicode Playing Cards block, e.g. "🂡"
+	NotationUnicode
+)
+
+var notationNames = map[Notation]string{
+	NotationAuto:    "auto",
+	NotationShort:   "short",
+	NotationASCII:   "ascii",
+	NotationLong:    "long",
+	NotationUnicode: "unicode",
+}
+
+var asciiFaces = map[Face]string{
+	Face2:     "2",
+	Face3:     "3",
+	Face4:     "4",
+	Face5:     "5",
+	Face6:     "6",
+	Face7:     "7",
+	Face8:     "8",
+	Face9:     "9",
+	Face10:    "T",
+	FaceJack:  "J",
+	FaceQueen: "Q",
+	FaceKing:  "K",
+	FaceAce:   "A",
+}
+
+var asciiSuits = map[Suit]string{
+	SuitDiamonds: "d",
+	SuitClubs:    "c",
+	SuitHearts:   "h",
+	SuitSpades:   "s",
+}
+
+var longFaces = map[Face]string{
+	Face2:     "Two",
+	Face3:     "Three",
+	Face4:     "Four",
+	Face5:     "Five",
+	Face6:     "Six",
+	Face7:     "Seven",
+	Face8:     "Eight",
+	Face9:     "Nine",
+	Face10:    "Ten",
+	FaceJack:  "Jack",
+	FaceQueen: "Queen",
+	FaceKing:  "King",
+	FaceAce:   "Ace",
+}
+
+var longSuits = map[Suit]string{
+	SuitDiamonds: "Diamonds",
+	SuitClubs:    "Clubs",
+	SuitHearts:   "Hearts",
+	SuitSpades:   "Spades",
+}
+
+const longSeparator = " of "
+
+const (
+	asciiJoker = "Jk"
+	longJoker  = "Joker"
+)
+
+// Unicode Playing Cards block: every suit takes 16 codepoints starting with the ace,
+// and the knight between the jack and the queen is not used in poker
+const (
+	unicodeCardsFirst rune = 0x1F0A0
+	unicodeCardsLast  rune = 0x1F0DF
+)
+
+var unicodeSuitBase = map[Suit]rune{
+	SuitSpades:   0x1F0A0,
+	SuitHearts:   0x1F0B0,
+	SuitDiamonds: 0x1F0C0,
+	SuitClubs:    0x1F0D0,
+}
+
+func (n Notation) String() string {
+	if name, ok := notationNames[n]; ok {
+		return name
+	}
+	return fmt.Sprintf("Notation(%d)", int(n))
+}
+
+// ParseNotation returns the notation with the given name, e.g. "ascii"
+func ParseNotation(name string) (Notation, error) {
+	for notation, notationName := range notationNames {
+		if notationName == strings.ToLower(name) {
+			return notation, nil
+		}
+	}
+	return 0, errors.New(fmt.Sprintf("unrecognized notation %s", name))
+}
+
+// Format writes the card in the given notation
+func (c Card) Format(notation Notation) (string, error) {
+	if c.IsJoker() {
+		return formatJoker(notation)
+	}
+	if !isValidSuit(c.Suit) || !isValidFace(c.Face) {
+		return "", errors.New(fmt.Sprintf("cannot format invalid card %s", c))
+	}
+	switch notation {
+	case NotationShort:
+		return c.ShortRepresentation()
+	case NotationASCII:
+		return asciiFaces[c.Face] + asciiSuits[c.Suit], nil
+	case NotationLong:
+		return longFaces[c.Face] + longSeparator + longSuits[c.Suit], nil
+	case NotationUnicode:
+		return string(unicodeSuitBase[c.Suit] + unicodeOffsetOf(c.Face)), nil
+	default:
+		return "", errors.New(fmt.Sprintf("cannot format card in %s notation", notation))
+	}
+}
+
+// Parse reads a card written in the given notation, NotationAuto detects it from the input.
+// Surrounding whitespace is ignored. Errors are of type *ParseError
+func Parse(notation Notation, representation string) (*Card, error) {
+	if notation == NotationAuto {
+		notation = DetectNotation(representation)
+	}
+	switch notation {
+	case NotationShort:
+		return FromShortRepresentation(representation)
+	case NotationASCII:
+		return parseASCII(representation)
+	case NotationLong:
+		return parseLong(representation)
+	case NotationUnicode:
+		return parseUnicode(representation)
+	default:
+		return nil, &ParseError{Input: representation, Reason: fmt.Sprintf("unsupported notation %s", notation)}
+	}
+}
+
+// ParseList reads comma separated cards, NotationAuto detects the notation of every card separately.
+// A blank list has no cards
+func ParseList(notation Notation, list string) ([]Card, error) {
+	if strings.TrimSpace(list) == "" {
+		return nil, nil
+	}
+	var cards []Card
+	for _, representation := range strings.Split(list, ",") {
+		parsed, err := Parse(notation, representation)
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, *parsed)
+	}
+	return cards, nil
+}
+
+// DetectNotation guesses the notation of a single card.
+// Unrecognized inputs are reported as NotationShort, so that parsing them reports a meaningful error
+func DetectNotation(representation string) Notation {
+	trimmed := strings.TrimSpace(representation)
+	first, _ := utf8.DecodeRuneInString(trimmed)
+	switch {
+	case first >= unicodeCardsFirst && first <= unicodeCardsLast:
+		return NotationUnicode
+	case strings.Contains(strings.ToLower(trimmed), longSeparator):
+		return NotationLong
+	case first < utf8.RuneSelf && trimmed != "":
+		return NotationASCII
+	default:
+		return NotationShort
+	}
+}
+
+func formatJoker(notation Notation) (string, error) {
+	switch notation {
+	case NotationShort, NotationUnicode:
+		return JokerUnicode, nil
+	case NotationASCII:
+		return asciiJoker, nil
+	case NotationLong:
+		return longJoker, nil
+	default:
+		return "", errors.New(fmt.Sprintf("cannot format card in %s notation", notation))
+	}
+}
+
+// parseJoker reports whether the trimmed input is a joker in any notation
+func parseJoker(trimmed string) (*Card, bool) {
+	if trimmed == JokerUnicode || strings.EqualFold(trimmed, asciiJoker) || strings.EqualFold(trimmed, longJoker) {
+		joker := Joker
+		return &joker, true
+	}
+	return nil, false
+}
+
+func unicodeOffsetOf(face Face) rune {
+	switch {
+	case face == FaceAce:
+		return 1
+	case face >= FaceQueen:
+		return rune(face) + 1
+	default:
+		return rune(face)
+	}
+}
+
+func parseASCII(representation string) (*Card, error) {
+	trimmed, offset := trimmedInput(representation)
+	if joker, ok := parseJoker(trimmed); ok {
+		return joker, nil
+	}
+	if len(trimmed) < 2 {
+		return nil, &ParseError{Input: representation, Offset: offset, Reason: "card is too short"}
+	}
+	faceName, suitName := trimmed[:len(trimmed)-1], trimmed[len(trimmed)-1:]
+
+	face, ok := ParseASCIIFace(faceName)
+	if !ok {
+		return nil, &ParseError{Input: representation, Offset: offset, Reason: fmt.Sprintf("unrecognized face %q", faceName)}
+	}
+	suit, ok := ParseASCIISuit(suitName)
+	if !ok {
+		return nil, &ParseError{
+			Input:  representation,
+			Offset: offset + len(faceName),
+			Reason: fmt.Sprintf("unrecognized suit %q", suitName),
+		}
+	}
+	return &Card{Suit: suit, Face: face}, nil
+}
+
+// ParseASCIIFace returns the face of an ASCII notation letter ignoring case, e.g. "T" or "q"; "10" is accepted too
+func ParseASCIIFace(name string) (Face, bool) {
+	for face, faceName := range asciiFaces {
+		if strings.EqualFold(faceName, name) {
+			return face, true
+		}
+	}
+	if name == faceNames[Face10] {
+		return Face10, true
+	}
+	return 0, false
+}
+
+// ParseASCIISuit returns the suit of an ASCII notation letter ignoring case, e.g. "s" or "H"
+func ParseASCIISuit(name string) (Suit, bool) {
+	for suit, suitName := range asciiSuits {
+		if strings.EqualFold(suitName, name) {
+			return suit, true
+		}
+	}
+	return 0, false
+}
+
+func parseLong(representation string) (*Card, error) {
+	trimmed, offset := trimmedInput(representation)
+	if joker, ok := parseJoker(trimmed); ok {
+		return joker, nil
+	}
+	separator := strings.Index(strings.ToLower(trimmed), longSeparator)
+	if separator < 0 {
+		return nil, &ParseError{Input: representation, Offset: offset, Reason: fmt.Sprintf("missing %q", longSeparator)}
+	}
+	faceName, suitName := trimmed[:separator], trimmed[separator+len(longSeparator):]
+
+	var face Face
+	for candidate, name := range longFaces {
+		if strings.EqualFold(name, faceName) || faceNames[candidate] == strings.ToUpper(faceName) {
+			face = candidate
+		}
+	}
+	if face == 0 {
+		return nil, &ParseError{Input: representation, Offset: offset, Reason: fmt.Sprintf("unrecognized face %q", faceName)}
+	}
+
+	var suit Suit
+	for candidate, name := range longSuits {
+		if strings.EqualFold(name, suitName) {
+			suit = candidate
+		}
+	}
+	if suit == 0 {
+		return nil, &ParseError{
+			Input:  representation,
+			Offset: offset + separator + len(longSeparator),
+			Reason: fmt.Sprintf("unrecognized suit %q", suitName),
+		}
+	}
+	return &Card{Suit: suit, Face: face}, nil
+}
+
+func parseUnicode(representation string) (*Card, error) {
+	trimmed, offset := trimmedInput(representation)
+	if joker, ok := parseJoker(trimmed); ok {
+		return joker, nil
+	}
+	symbol, size := utf8.DecodeRuneInString(trimmed)
+	if size != len(trimmed) || symbol < unicodeCardsFirst || symbol > unicodeCardsLast {
+		return nil, &ParseError{Input: representation, Offset: offset, Reason: "not a single playing card symbol"}
+	}
+	for suit, base := range unicodeSuitBase {
+		if symbol <= base || symbol-base > unicodeOffsetOf(FaceKing) {
+			continue
+		}
+		for _, face := range Faces() {
+			if base+unicodeOffsetOf(face) == symbol {
+				return &Card{Suit: suit, Face: face}, nil
+			}
+		}
+	}
+	return nil, &ParseError{Input: representation, Offset: offset, Reason: fmt.Sprintf("%U is not a poker card", symbol)}
+}
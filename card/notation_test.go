@@ -0,0 +1,158 @@
+package card
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCard_Format(t *testing.T) {
+	aceOfSpades := Card{Suit: SuitSpades, Face: FaceAce}
+	tenOfDiamonds := Card{Suit: SuitDiamonds, Face: Face10}
+	queenOfClubs := Card{Suit: SuitClubs, Face: FaceQueen}
+
+	tests := []struct {
+		notation Notation
+		card     Card
+		expected string
+	}{
+		{NotationShort, aceOfSpades, "♠A"},
+		{NotationShort, tenOfDiamonds, "♦10"},
+		{NotationASCII, aceOfSpades, "As"},
+		{NotationASCII, tenOfDiamonds, "Td"},
+		{NotationASCII, queenOfClubs, "Qc"},
+		{NotationLong, aceOfSpades, "Ace of Spades"},
+		{NotationLong, tenOfDiamonds, "Ten of Diamonds"},
+		{NotationUnicode, aceOfSpades, "\U0001F0A1"},
+		{NotationUnicode, tenOfDiamonds, "\U0001F0CA"},
+		{NotationUnicode, queenOfClubs, "\U0001F0DD"},
+	}
+	for _, test := range tests {
+		t.Run(test.notation.String()+" "+test.expected, func(t *testing.T) {
+			formatted, err := test.card.Format(test.notation)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, formatted)
+		})
+	}
+
+	t.Run("invalid card", func(t *testing.T) {
+		_, err := Card{Suit: SuitSpades}.Format(NotationASCII)
+		require.Error(t, err)
+	})
+	t.Run("auto cannot be formatted", func(t *testing.T) {
+		_, err := aceOfSpades.Format(NotationAuto)
+		require.Error(t, err)
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("every card round trips in every notation", func(t *testing.T) {
+		for _, notation := range []Notation{NotationShort, NotationASCII, NotationLong, NotationUnicode} {
+			for _, suit := range Suits() {
+				for _, face := range Faces() {
+					c := Card{Suit: suit, Face: face}
+					formatted, err := c.Format(notation)
+					require.NoError(t, err)
+
+					parsed, err := Parse(notation, formatted)
+					require.NoError(t, err)
+					assert.Equal(t, c, *parsed)
+
+					detected, err := Parse(NotationAuto, formatted)
+					require.NoError(t, err)
+					assert.Equal(t, c, *detected)
+				}
+			}
+		}
+	})
+	t.Run("ascii is case insensitive and accepts 10", func(t *testing.T) {
+		parsed, err := Parse(NotationASCII, " tH\r\n")
+		require.NoError(t, err)
+		assert.Equal(t, Card{Suit: SuitHearts, Face: Face10}, *parsed)
+		parsed, err = Parse(NotationASCII, "10c")
+		require.NoError(t, err)
+		assert.Equal(t, Card{Suit: SuitClubs, Face: Face10}, *parsed)
+	})
+	t.Run("long is case insensitive", func(t *testing.T) {
+		parsed, err := Parse(NotationAuto, "queen OF hearts")
+		require.NoError(t, err)
+		assert.Equal(t, Card{Suit: SuitHearts, Face: FaceQueen}, *parsed)
+	})
+	t.Run("ascii invalid suit", func(t *testing.T) {
+		_, err := Parse(NotationASCII, "Ax")
+		var parseError *ParseError
+		require.ErrorAs(t, err, &parseError)
+		assert.Equal(t, 1, parseError.Offset)
+	})
+	t.Run("long invalid suit", func(t *testing.T) {
+		_, err := Parse(NotationLong, "Ace of Swords")
+		var parseError *ParseError
+		require.ErrorAs(t, err, &parseError)
+		assert.Equal(t, 7, parseError.Offset)
+	})
+	t.Run("unicode knight is not a poker card", func(t *testing.T) {
+		_, err := Parse(NotationUnicode, "\U0001F0AC")
+		var parseError *ParseError
+		require.ErrorAs(t, err, &parseError)
+	})
+}
+
+func TestParseList(t *testing.T) {
+	t.Run("mixed notations", func(t *testing.T) {
+		cards, err := ParseList(NotationAuto, "As, ♥K,Queen of Clubs")
+		require.NoError(t, err)
+		assert.Equal(t, []Card{
+			{Suit: SuitSpades, Face: FaceAce},
+			{Suit: SuitHearts, Face: FaceKing},
+			{Suit: SuitClubs, Face: FaceQueen},
+		}, cards)
+	})
+	t.Run("blank list", func(t *testing.T) {
+		cards, err := ParseList(NotationAuto, " ")
+		require.NoError(t, err)
+		assert.Empty(t, cards)
+	})
+	t.Run("invalid card", func(t *testing.T) {
+		_, err := ParseList(NotationAuto, "As,,Kh")
+		var parseError *ParseError
+		require.ErrorAs(t, err, &parseError)
+	})
+}
+
+func TestDetectNotation(t *testing.T) {
+	assert.Equal(t, NotationShort, DetectNotation("♠A"))
+	assert.Equal(t, NotationASCII, DetectNotation("As"))
+	assert.Equal(t, NotationLong, DetectNotation("Ace of Spades"))
+	assert.Equal(t, NotationUnicode, DetectNotation("\U0001F0A1"))
+}
+
+func TestParseNotation(t *testing.T) {
+	notation, err := ParseNotation("ASCII")
+	require.NoError(t, err)
+	assert.Equal(t, NotationASCII, notation)
+	_, err = ParseNotation("morse")
+	require.Error(t, err)
+}
+
+func TestJokerNotation(t *testing.T) {
+	expected := map[Notation]string{
+		NotationShort:   JokerUnicode,
+		NotationASCII:   "Jk",
+		NotationLong:    "Joker",
+		NotationUnicode: JokerUnicode,
+	}
+	for notation, representation := range expected {
+		formatted, err := Joker.Format(notation)
+		require.NoError(t, err)
+		assert.Equal(t, representation, formatted)
+
+		parsed, err := Parse(NotationAuto, formatted)
+		require.NoError(t, err)
+		assert.True(t, parsed.IsJoker())
+	}
+
+	restored, err := FromByte(Joker.Byte())
+	require.NoError(t, err)
+	assert.Equal(t, Joker, *restored)
+	assert.Equal(t, "Joker", Joker.String())
+}
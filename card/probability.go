@@ -0,0 +1,24 @@
+package card
+
+// FiveCardHands is the count of distinct five-card hands of a standard deck
+const FiveCardHands = 2_598_960
+
+// handCounts are the counts of five-card hands of a standard deck making every combination
+var handCounts = map[int]int{
+	RankHighCard:      1_302_540,
+	RankPair:          1_098_240,
+	RankTwoPairs:      123_552,
+	RankThreeOfAKind:  54_912,
+	RankStraight:      10_200,
+	RankFlush:         5_108,
+	RankFullHouse:     3_744,
+	RankFourOfAKind:   624,
+	RankStraightFlush: 36,
+	RankRoyalFlush:    4,
+}
+
+// Probability is the chance that five cards dealt from a standard deck make a combination of the rank,
+// 0 for unknown ranks and Five Of A Kind, which needs wild cards
+func Probability(rank int) float64 {
+	return float64(handCounts[rank]) / FiveCardHands
+}
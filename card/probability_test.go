@@ -0,0 +1,37 @@
+package card
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestProbability(t *testing.T) {
+	t.Run("matches every hand of a deck", func(t *testing.T) {
+		deck := encodeAll(t, fullDeck())
+		counts := map[int]int{}
+		hands := 0
+		for a := 0; a < len(deck); a++ {
+			for b := a + 1; b < len(deck); b++ {
+				for c := b + 1; c < len(deck); c++ {
+					for d := c + 1; d < len(deck); d++ {
+						for e := d + 1; e < len(deck); e++ {
+							strength := Evaluate(deck[a], deck[b], deck[c], deck[d], deck[e])
+							counts[BasicPokerCombination{strength: strength}.Rank()]++
+							hands++
+						}
+					}
+				}
+			}
+		}
+		assert.Equal(t, FiveCardHands, hands)
+		assert.Equal(t, handCounts, counts)
+	})
+	t.Run("sums to one", func(t *testing.T) {
+		total := 0.0
+		for _, rank := range Ranks() {
+			total += Probability(rank)
+		}
+		assert.InDelta(t, 1.0, total, 1e-12)
+		assert.Equal(t, 0.0, Probability(0))
+	})
+}
@@ -0,0 +1,81 @@
+package card
+
+import (
+	"errors"
+	"fmt"
+	"golang.org/x/exp/slices"
+)
+
+// Wilds are the faces that substitute for any card, e.g. DeucesWild. Jokers are always wild
+type Wilds []Face
+
+// DeucesWild makes every two a wild card
+var DeucesWild = Wilds{Face2}
+
+// IsWild reports whether the card substitutes for any card
+func (w Wilds) IsWild(c Card) bool {
+	return c.IsJoker() || slices.Contains(w, c.Face)
+}
+
+// CombinationWithWilds returns the strongest combination of the cards when every wild card is substituted
+// for any card, one of the hand included, so four aces and a joker make Five Of A Kind.
+// The combination keeps the cards as they are, without substitutions
+func CombinationWithWilds(cards []Card, wilds Wilds) (PokerCombination, error) {
+	if len(cards) != ValidCombinationSize {
+		return nil, errors.New(fmt.Sprintf("combination must have %d cards, got %d", ValidCombinationSize, len(cards)))
+	}
+	var natural []Card
+	for _, card := range cards {
+		if wilds.IsWild(card) {
+			continue
+		}
+		if _, err := card.Encode(); err != nil {
+			return nil, err
+		}
+		natural = append(natural, card)
+	}
+	if len(natural) == ValidCombinationSize {
+		return CombinationOf(cards)
+	}
+	return BasicPokerCombination{strength: bestSubstitution(natural), cards: cards}, nil
+}
+
+// bestSubstitution tries every multiset of faces for the wild cards completing the natural ones.
+// Suits only matter for flushes, so the wild cards take the suit of the first natural card:
+// either all natural cards share it or there is no flush anyway
+func bestSubstitution(natural []Card) int {
+	suit := SuitSpades
+	if len(natural) > 0 {
+		suit = natural[0].Suit
+	}
+	hand := append(make([]Card, 0, ValidCombinationSize), natural...)
+	var encoded [ValidCombinationSize]EncodedCard
+	for i, card := range natural {
+		encoded[i], _ = card.Encode()
+	}
+
+	best := 0
+	var substitute func(lowest Face)
+	substitute = func(lowest Face) {
+		if len(hand) == ValidCombinationSize {
+			strength := Evaluate(encoded[0], encoded[1], encoded[2], encoded[3], encoded[4])
+			if strength == 0 {
+				// repeated cards are not in the tables
+				strength = classify(hand)
+			}
+			if strength > best {
+				best = strength
+			}
+			return
+		}
+		for face := lowest; face <= FaceAce; face++ {
+			substitution := Card{Suit: suit, Face: face}
+			encoded[len(hand)], _ = substitution.Encode()
+			hand = append(hand, substitution)
+			substitute(face)
+			hand = hand[:len(hand)-1]
+		}
+	}
+	substitute(Face2)
+	return best
+}
@@ -0,0 +1,75 @@
+package card
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCombinationWithWilds(t *testing.T) {
+	withWilds := func(t *testing.T, wilds Wilds, representations ...string) PokerCombination {
+		t.Helper()
+		combination, err := CombinationWithWilds(cardsOf(t, representations...), wilds)
+		require.NoError(t, err)
+		return combination
+	}
+
+	t.Run("joker completes five of a kind", func(t *testing.T) {
+		combination := combinationOf(t, "♠A", "♦A", "♥A", "♣A", JokerUnicode)
+		assert.Equal(t, RankFiveOfAKind, combination.Rank())
+		assert.Equal(t, CombinationFiveOfAKind, combination.Name())
+		assert.Equal(t, []int{14}, combination.Kickers())
+		assert.Equal(t, 1, Compare(combination, combinationOf(t, "♠A", "♠K", "♠Q", "♠J", "♠10")))
+		representation, err := combination.Representation()
+		require.NoError(t, err)
+		assert.Equal(t, "♠A,♦A,♥A,♣A,"+JokerUnicode+" | Five Of A Kind", representation)
+	})
+	t.Run("joker picks the best substitution", func(t *testing.T) {
+		flush := combinationOf(t, "♠2", "♠7", "♠9", "♠J", JokerUnicode)
+		assert.Equal(t, RankFlush, flush.Rank())
+		assert.Equal(t, []int{14, 11, 9, 7, 2}, flush.Kickers())
+
+		royal := combinationOf(t, "♥A", "♥K", "♥J", "♥10", JokerUnicode)
+		assert.Equal(t, RankRoyalFlush, royal.Rank())
+
+		straight := combinationOf(t, "♠2", "♦3", "♥4", "♠5", JokerUnicode)
+		assert.Equal(t, RankStraight, straight.Rank())
+		assert.Equal(t, []int{6}, straight.Kickers())
+
+		trips := combinationOf(t, "♠2", "♦2", "♥9", "♠K", JokerUnicode)
+		assert.Equal(t, RankThreeOfAKind, trips.Rank())
+		assert.Equal(t, []int{2, 13, 9}, trips.Kickers())
+
+		pair := combinationOf(t, "♠2", "♦7", "♥9", "♠K", JokerUnicode)
+		assert.Equal(t, RankPair, pair.Rank())
+		assert.Equal(t, []int{13, 9, 7, 2}, pair.Kickers())
+	})
+	t.Run("every card wild", func(t *testing.T) {
+		combination := withWilds(t, DeucesWild, "♠2", "♦2", "♥2", "♣2", JokerUnicode)
+		assert.Equal(t, RankFiveOfAKind, combination.Rank())
+		assert.Equal(t, []int{14}, combination.Kickers())
+	})
+	t.Run("deuces wild", func(t *testing.T) {
+		combination := withWilds(t, DeucesWild, "♠2", "♦2", "♥9", "♥8", "♥7")
+		assert.Equal(t, RankStraightFlush, combination.Rank())
+		assert.Equal(t, []int{11}, combination.Kickers())
+
+		combination = withWilds(t, DeucesWild, "♠2", "♦2", "♥9", "♣9", "♥7")
+		assert.Equal(t, RankFourOfAKind, combination.Rank())
+		assert.Equal(t, []int{9, 7}, combination.Kickers())
+
+		natural, err := CombinationOf(cardsOf(t, "♠2", "♦2", "♥9", "♣9", "♥7"))
+		require.NoError(t, err)
+		assert.Equal(t, RankTwoPairs, natural.Rank())
+	})
+	t.Run("without wild cards", func(t *testing.T) {
+		combination := withWilds(t, DeucesWild, "♠3", "♦3", "♥9", "♣9", "♥7")
+		assert.Equal(t, combinationOf(t, "♠3", "♦3", "♥9", "♣9", "♥7"), combination)
+	})
+	t.Run("invalid hands", func(t *testing.T) {
+		_, err := CombinationWithWilds(cardsOf(t, "♠3", "♦3", JokerUnicode), nil)
+		require.Error(t, err)
+		_, err = CombinationWithWilds([]Card{{Face: Face2}, Joker, Joker, Joker, Joker}, nil)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/card"
+	"log"
+	"math/rand"
+	"os"
+	"path/filepath"
+)
+
+// The defaults reproduce the dataset/ directory of the repository
+var (
+	seed               = flag.Int64("seed", 1665694295623135151, "seed of the random generator")
+	files              = flag.Int("files", 100, "count of files to generate")
+	minCards           = flag.Int("min", 10, "minimal count of cards in a file")
+	maxCards           = flag.Int("max", 16, "maximal count of cards in a file, inclusive")
+	outputDir          = flag.String("out", "dataset", "directory to write files to")
+	withoutReplacement = flag.Bool("deck", false, "deal cards from a shuffled deck, so a file never repeats a card")
+	notationName       = flag.String("notation", card.NotationShort.String(), "notation of cards: short, ascii, long or unicode")
+)
+
+func generateFile(random *rand.Rand, fileName string, notation card.Notation) error {
+	cardsInFile := random.Intn(*maxCards-*minCards+1) + *minCards
+	var cards []card.Card
+	var err error
+	if *withoutReplacement {
+		cards, err = card.DealtCards(random, cardsInFile)
+	} else {
+		cards, err = card.RandomCards(random, cardsInFile)
+	}
+	if err != nil {
+		return err
+	}
+	log.Printf("Generated cards %s\n", cards)
+
+	summary, err := card.FormatCards(cards, notation)
+	if err != nil {
+		return err
+	}
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if err = writer.Write(summary); err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func main() {
+	flag.Parse()
+	notation, err := card.ParseNotation(*notationName)
+	if err != nil || notation == card.NotationAuto {
+		log.Fatalf("unsupported notation %s", *notationName)
+	}
+	if *minCards < 1 || *maxCards < *minCards {
+		log.Fatalf("invalid size range [%d, %d]", *minCards, *maxCards)
+	}
+	if *withoutReplacement && *maxCards > card.StandardDeckSize {
+		log.Fatalf("a deck has only %d cards, cannot deal %d", card.StandardDeckSize, *maxCards)
+	}
+	if err = os.MkdirAll(*outputDir, os.ModePerm); err != nil {
+		log.Fatalln(err)
+	}
+
+	random := rand.New(rand.NewSource(*seed))
+	log.Printf("Initialized random with seed %d\n", *seed)
+
+	fmt.Println("Starting to generate cards...")
+	for i := 0; i < *files; i++ {
+		log.Printf("Iteration %d\n", i)
+		fileName := filepath.Join(*outputDir, fmt.Sprintf("dat%d.csv", i))
+		if err = generateFile(random, fileName, notation); err != nil {
+			log.Fatalln("failed to generate file", fileName, err)
+		}
+	}
+}
@@ -1,9 +1,14 @@
 package combinatorics
 
 import (
-	combo "github.com/natemcintosh/gocombinatorics"
+	"errors"
+	"fmt"
+	"math/bits"
 )
 
+// maskLimit is the most elements EachMask supports
+const maskLimit = 64
+
 func Deduplicate[T comparable](elements []T) []T {
 	allKeys := map[T]bool{}
 	var list []T
@@ -17,17 +22,156 @@ func Deduplicate[T comparable](elements []T) []T {
 	return list
 }
 
-func Combinations[T comparable](elements []T, sliceSize int) ([][]T, error) {
-	combination, err := combo.NewCombinations(elements, sliceSize)
+func validateSize(n int, k int) error {
+	switch {
+	case n <= 0:
+		return errors.New("elements must not be empty")
+	case k <= 0:
+		return errors.New(fmt.Sprintf("slice size must be positive, got %d", k))
+	case k > n:
+		return errors.New(fmt.Sprintf("slice size %d is larger than %d elements", k, n))
+	default:
+		return nil
+	}
+}
+
+// nextIndices advances indices to the next k-subset of [0, n) in lexicographic order
+// and reports whether there was one
+func nextIndices(indices []int, n int) bool {
+	k := len(indices)
+	i := k - 1
+	for i >= 0 && indices[i] == n-k+i {
+		i--
+	}
+	if i < 0 {
+		return false
+	}
+	indices[i]++
+	for j := i + 1; j < k; j++ {
+		indices[j] = indices[j-1] + 1
+	}
+	return true
+}
+
+// EachCombination calls yield with every subset of sliceSize elements in lexicographic order
+// of their indices, without holding all of them in memory. The slice passed to yield is reused
+// between calls, so it has to be copied to be kept. Returning false from yield stops the iteration
+func EachCombination[T any](elements []T, sliceSize int, yield func([]T) bool) error {
+	if err := validateSize(len(elements), sliceSize); err != nil {
+		return err
+	}
+	if len(elements) <= maskLimit {
+		return eachCombinationByMask(elements, sliceSize, yield)
+	}
+	count, err := Count(len(elements), sliceSize)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	return EachCombinationInRange(elements, sliceSize, 0, count, yield)
+}
+
+// EachCombinationInRange is EachCombination limited to the subsets with lexicographic
+// ranks in [from, to), see Unrank. It allows splitting the subsets into independent shards
+func EachCombinationInRange[T any](elements []T, sliceSize int, from uint64, to uint64, yield func([]T) bool) error {
+	if from >= to {
+		return nil
+	}
+	indices, err := Unrank(from, len(elements), sliceSize)
+	if err != nil {
+		return err
 	}
+	items := make([]T, sliceSize)
+	for rank := from; rank < to; rank++ {
+		for i, index := range indices {
+			items[i] = elements[index]
+		}
+		if !yield(items) {
+			return nil
+		}
+		if !nextIndices(indices, len(elements)) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// eachCombinationByMask is EachCombination of at most maskLimit elements built on Gosper's hack. Masks of the elements
+// left out increase, so masks of the chosen ones with the first element in the highest bit decrease,
+// which is the lexicographic order of their indices. Only the elements after the first changed one
+// are looked up again
+func eachCombinationByMask[T any](elements []T, sliceSize int, yield func([]T) bool) error {
+	n := len(elements)
+	all := uint64(1)<<n - 1
+	items := make([]T, sliceSize)
+	left, ok := uint64(0), true
+	if sliceSize < n {
+		left = firstMask(n - sliceSize)
+	}
+	previous := uint64(0)
+	for ; ok; left, ok = nextMask(left, n) {
+		// element i is bit n-1-i of the mask, shifting puts it at bit 63-i
+		chosen := (all &^ left) << (maskLimit - n)
+		changed := bits.LeadingZeros64(chosen ^ previous)
+		previous = chosen
+		kept := ^uint64(0) >> changed
+		for i := bits.OnesCount64(chosen &^ kept); i < sliceSize; i++ {
+			index := bits.LeadingZeros64(chosen & kept)
+			items[i] = elements[index]
+			kept &^= 1 << (maskLimit - 1 - index)
+		}
+		if !yield(items) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func Combinations[T comparable](elements []T, sliceSize int) ([][]T, error) {
 	var result [][]T
-	for combination.Next() {
-		items := combination.Items()
+	err := EachCombination(elements, sliceSize, func(items []T) bool {
 		copied := make([]T, len(items))
 		copy(copied, items)
 		result = append(result, copied)
+		return true
+	})
+	if err != nil {
+		return nil, err
 	}
 	return result, nil
 }
+
+// EachMask calls yield with every n-bit mask that has k bits set, in increasing order.
+// It uses Gosper's hack, so it only works for n up to 64
+func EachMask(n int, k int, yield func(uint64) bool) error {
+	if err := validateSize(n, k); err != nil {
+		return err
+	}
+	if n > maskLimit {
+		return errors.New(fmt.Sprintf("masks are limited to %d elements, got %d", maskLimit, n))
+	}
+	for mask, ok := firstMask(k), true; ok; mask, ok = nextMask(mask, n) {
+		if !yield(mask) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// firstMask is the lowest mask with k bits set
+func firstMask(k int) uint64 {
+	if k == maskLimit {
+		return ^uint64(0)
+	}
+	return uint64(1)<<k - 1
+}
+
+// nextMask is Gosper's hack: it moves the lowest movable bit one position up and puts the bits
+// below it back to the bottom. It reports false when there is no larger n-bit mask
+func nextMask(mask uint64, n int) (uint64, bool) {
+	lowest := mask & -mask
+	ripple := mask + lowest
+	if ripple == 0 || (n < maskLimit && ripple >= uint64(1)<<n) {
+		return 0, false
+	}
+	return ripple | (mask^ripple)>>(2+bits.TrailingZeros64(lowest)), true
+}
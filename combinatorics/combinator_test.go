@@ -9,10 +9,10 @@ import (
 	"testing"
 )
 
-func Test_deduplicate(t *testing.T) {
+func Test_Deduplicate(t *testing.T) {
 	t.Run("int slice", func(t *testing.T) {
 		ints := []int{1, 2, 3, 3, 4, 5, 5, 6}
-		deduplicated := deduplicate(ints)
+		deduplicated := Deduplicate(ints)
 		assert.Equal(t, 6, len(deduplicated))
 		assert.True(t, slices.Contains(deduplicated, 1))
 		assert.True(t, slices.Contains(deduplicated, 2))
@@ -30,7 +30,7 @@ func Test_deduplicate(t *testing.T) {
 			{Face: card.Face10, Suit: card.SuitHearts},
 			{Face: card.Face10, Suit: card.SuitClubs},
 		}
-		deduplicated := deduplicate(cards)
+		deduplicated := Deduplicate(cards)
 		assert.Equal(t, 4, len(deduplicated))
 		assert.True(t, slices.Contains(cards, card.Card{Face: card.Face2, Suit: card.SuitSpades}))
 		assert.True(t, slices.Contains(cards, card.Card{Face: card.Face2, Suit: card.SuitDiamonds}))
@@ -42,7 +42,7 @@ func Test_deduplicate(t *testing.T) {
 func Test_combinations(t *testing.T) {
 	t.Run("int slice", func(t *testing.T) {
 		ints := []int{1, 2, 3, 4, 5, 6, 7}
-		result, err := combinations(ints, 2)
+		result, err := Combinations(ints, 2)
 		require.NoError(t, err)
 		assert.Equal(t, 21, len(result))
 		for i := 0; i < len(result); i++ {
@@ -59,7 +59,7 @@ func Test_combinations(t *testing.T) {
 			{Face: card.FaceQueen, Suit: card.SuitHearts},
 			{Face: card.FaceAce, Suit: card.SuitClubs},
 		}
-		result, err := combinations(cards, 2)
+		result, err := Combinations(cards, 2)
 		log.Println(result)
 		require.NoError(t, err)
 		assert.Equal(t, 21, len(result))
@@ -77,7 +77,7 @@ func Test_combinations(t *testing.T) {
 			{Face: card.FaceQueen, Suit: card.SuitHearts},
 			{Face: card.FaceAce, Suit: card.SuitClubs},
 		}
-		result, err := combinations(cards, 5)
+		result, err := Combinations(cards, 5)
 		log.Println(result)
 		require.NoError(t, err)
 		assert.Equal(t, 21, len(result))
@@ -86,3 +86,153 @@ func Test_combinations(t *testing.T) {
 		}
 	})
 }
+
+func TestEachCombination(t *testing.T) {
+	t.Run("visits every subset in order", func(t *testing.T) {
+		var visited [][]int
+		err := EachCombination([]int{1, 2, 3, 4}, 2, func(items []int) bool {
+			visited = append(visited, append([]int(nil), items...))
+			return true
+		})
+		require.NoError(t, err)
+		assert.Equal(t, [][]int{{1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}}, visited)
+	})
+	t.Run("reuses the buffer", func(t *testing.T) {
+		var first []int
+		err := EachCombination([]int{1, 2, 3}, 2, func(items []int) bool {
+			if first == nil {
+				first = items
+			}
+			return true
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []int{2, 3}, first)
+	})
+	t.Run("stops early", func(t *testing.T) {
+		count := 0
+		err := EachCombination([]int{1, 2, 3, 4, 5, 6, 7}, 5, func(items []int) bool {
+			count++
+			return count < 3
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+	})
+	t.Run("masks agree with indices", func(t *testing.T) {
+		for _, size := range [][2]int{{1, 1}, {6, 6}, {9, 4}, {12, 7}, {64, 1}, {64, 63}, {65, 2}} {
+			elements := make([]int, size[0])
+			for i := range elements {
+				elements[i] = i
+			}
+			count, err := Count(size[0], size[1])
+			require.NoError(t, err)
+			var expected, visited [][]int
+			require.NoError(t, EachCombinationInRange(elements, size[1], 0, count, func(items []int) bool {
+				expected = append(expected, append([]int(nil), items...))
+				return true
+			}))
+			require.NoError(t, EachCombination(elements, size[1], func(items []int) bool {
+				visited = append(visited, append([]int(nil), items...))
+				return true
+			}))
+			assert.Equal(t, expected, visited, size)
+		}
+	})
+	t.Run("too large subset", func(t *testing.T) {
+		err := EachCombination([]int{1, 2}, 3, func(items []int) bool {
+			return true
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestEachCombinationInRange(t *testing.T) {
+	elements := []int{0, 1, 2, 3, 4, 5, 6, 7, 8}
+	all, err := Combinations(elements, 4)
+	require.NoError(t, err)
+
+	t.Run("shards join into all subsets", func(t *testing.T) {
+		var joined [][]int
+		for from := uint64(0); from < uint64(len(all)); from += 10 {
+			to := from + 10
+			if to > uint64(len(all)) {
+				to = uint64(len(all))
+			}
+			err := EachCombinationInRange(elements, 4, from, to, func(items []int) bool {
+				joined = append(joined, append([]int(nil), items...))
+				return true
+			})
+			require.NoError(t, err)
+		}
+		assert.Equal(t, all, joined)
+	})
+	t.Run("empty range", func(t *testing.T) {
+		called := false
+		err := EachCombinationInRange(elements, 4, 5, 5, func(items []int) bool {
+			called = true
+			return true
+		})
+		require.NoError(t, err)
+		assert.False(t, called)
+	})
+	t.Run("range past the end", func(t *testing.T) {
+		err := EachCombinationInRange(elements, 4, uint64(len(all)), uint64(len(all))+1, func(items []int) bool {
+			return true
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestEachMask(t *testing.T) {
+	t.Run("masks of 5 out of 7", func(t *testing.T) {
+		var masks []uint64
+		err := EachMask(7, 5, func(mask uint64) bool {
+			masks = append(masks, mask)
+			return true
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 21, len(masks))
+		assert.Equal(t, uint64(0b0011111), masks[0])
+		assert.Equal(t, uint64(0b1111100), masks[len(masks)-1])
+		for i := 1; i < len(masks); i++ {
+			assert.Less(t, masks[i-1], masks[i])
+		}
+	})
+	t.Run("full width", func(t *testing.T) {
+		count := 0
+		err := EachMask(64, 2, func(mask uint64) bool {
+			count++
+			return true
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2016, count)
+	})
+	t.Run("more than 64 elements", func(t *testing.T) {
+		err := EachMask(65, 2, func(mask uint64) bool {
+			return true
+		})
+		require.Error(t, err)
+	})
+}
+
+func BenchmarkEachCombination(b *testing.B) {
+	elements := make([]int, 52)
+	for i := range elements {
+		elements[i] = i
+	}
+	count, err := Count(len(elements), 5)
+	require.NoError(b, err)
+	b.Run("masks", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = EachCombination(elements, 5, func(items []int) bool {
+				return true
+			})
+		}
+	})
+	b.Run("indices", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = EachCombinationInRange(elements, 5, 0, count, func(items []int) bool {
+				return true
+			})
+		}
+	})
+}
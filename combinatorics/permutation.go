@@ -0,0 +1,48 @@
+package combinatorics
+
+// EachPermutation calls yield with every ordered selection of sliceSize elements
+// in lexicographic order of their indices. Like EachCombination, the slice passed
+// to yield is reused and returning false stops the iteration
+func EachPermutation[T any](elements []T, sliceSize int, yield func([]T) bool) error {
+	if err := validateSize(len(elements), sliceSize); err != nil {
+		return err
+	}
+
+	items := make([]T, sliceSize)
+	used := make([]bool, len(elements))
+	var permute func(position int) bool
+	permute = func(position int) bool {
+		if position == sliceSize {
+			return yield(items)
+		}
+		for index, element := range elements {
+			if used[index] {
+				continue
+			}
+			used[index] = true
+			items[position] = element
+			proceed := permute(position + 1)
+			used[index] = false
+			if !proceed {
+				return false
+			}
+		}
+		return true
+	}
+	permute(0)
+	return nil
+}
+
+func Permutations[T any](elements []T, sliceSize int) ([][]T, error) {
+	var result [][]T
+	err := EachPermutation(elements, sliceSize, func(items []T) bool {
+		copied := make([]T, len(items))
+		copy(copied, items)
+		result = append(result, copied)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,33 @@
+package combinatorics
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPermutations(t *testing.T) {
+	t.Run("ordered pairs", func(t *testing.T) {
+		result, err := Permutations([]string{"a", "b", "c"}, 2)
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{{"a", "b"}, {"a", "c"}, {"b", "a"}, {"b", "c"}, {"c", "a"}, {"c", "b"}}, result)
+	})
+	t.Run("count of full permutations", func(t *testing.T) {
+		result, err := Permutations([]int{1, 2, 3, 4, 5}, 5)
+		require.NoError(t, err)
+		assert.Equal(t, 120, len(result))
+	})
+	t.Run("stops early", func(t *testing.T) {
+		count := 0
+		err := EachPermutation([]int{1, 2, 3, 4}, 3, func(items []int) bool {
+			count++
+			return count < 5
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 5, count)
+	})
+	t.Run("invalid size", func(t *testing.T) {
+		_, err := Permutations([]int{1, 2}, 3)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,92 @@
+package combinatorics
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// Count returns the number of k-subsets of n elements, the binomial coefficient.
+// An error is returned when the result does not fit into uint64
+func Count(n int, k int) (uint64, error) {
+	if n < 0 || k < 0 {
+		return 0, errors.New(fmt.Sprintf("cannot count %d-subsets of %d elements", k, n))
+	}
+	if k > n {
+		return 0, nil
+	}
+	if k > n-k {
+		k = n - k
+	}
+	result := uint64(1)
+	for i := 1; i <= k; i++ {
+		// result * (n - k + i) is always divisible by i, keep the full product in 128 bits
+		high, low := bits.Mul64(result, uint64(n-k+i))
+		if high >= uint64(i) {
+			return 0, errors.New(fmt.Sprintf("count of %d-subsets of %d elements overflows uint64", k, n))
+		}
+		result, _ = bits.Div64(high, low, uint64(i))
+	}
+	return result, nil
+}
+
+// mustCount is Count for arguments already known not to overflow
+func mustCount(n int, k int) uint64 {
+	count, err := Count(n, k)
+	if err != nil {
+		return math.MaxUint64
+	}
+	return count
+}
+
+// Rank returns the position of the sorted indices among all k-subsets of [0, n) in lexicographic order
+func Rank(indices []int, n int) (uint64, error) {
+	k := len(indices)
+	if _, err := Count(n, k); err != nil {
+		return 0, err
+	}
+	var rank uint64
+	previous := -1
+	for i, index := range indices {
+		if index <= previous || index >= n {
+			return 0, errors.New(fmt.Sprintf("indices %v are not a sorted subset of [0, %d)", indices, n))
+		}
+		for skipped := previous + 1; skipped < index; skipped++ {
+			rank += mustCount(n-1-skipped, k-1-i)
+		}
+		previous = index
+	}
+	return rank, nil
+}
+
+// Unrank returns the sorted indices of the k-subset of [0, n) with the given lexicographic rank
+func Unrank(rank uint64, n int, k int) ([]int, error) {
+	if err := validateSize(n, k); err != nil {
+		return nil, err
+	}
+	count, err := Count(n, k)
+	if err != nil {
+		return nil, err
+	}
+	if rank >= count {
+		return nil, errors.New(fmt.Sprintf("rank %d is out of %d subsets", rank, count))
+	}
+
+	indices := make([]int, k)
+	candidate := 0
+	for i := 0; i < k; i++ {
+		for {
+			// subsets that start with candidate at position i
+			withCandidate := mustCount(n-1-candidate, k-1-i)
+			if rank < withCandidate {
+				break
+			}
+			rank -= withCandidate
+			candidate++
+		}
+		indices[i] = candidate
+		candidate++
+	}
+	return indices, nil
+}
@@ -0,0 +1,77 @@
+package combinatorics
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCount(t *testing.T) {
+	t.Run("small values", func(t *testing.T) {
+		count, err := Count(7, 5)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(21), count)
+
+		count, err = Count(52, 5)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2598960), count)
+
+		count, err = Count(5, 7)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), count)
+
+		count, err = Count(0, 0)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), count)
+	})
+	t.Run("largest value that fits", func(t *testing.T) {
+		count, err := Count(67, 33)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(14226520737620288370), count)
+	})
+	t.Run("overflow", func(t *testing.T) {
+		_, err := Count(68, 34)
+		require.Error(t, err)
+	})
+	t.Run("negative", func(t *testing.T) {
+		_, err := Count(-1, 2)
+		require.Error(t, err)
+	})
+}
+
+func TestRank(t *testing.T) {
+	t.Run("rank and unrank are inverse", func(t *testing.T) {
+		indices := []int{0, 1, 2, 3}
+		rank := uint64(0)
+		for {
+			computed, err := Rank(indices, 8)
+			require.NoError(t, err)
+			assert.Equal(t, rank, computed)
+
+			unranked, err := Unrank(rank, 8, 4)
+			require.NoError(t, err)
+			assert.Equal(t, indices, unranked)
+
+			rank++
+			if !nextIndices(indices, 8) {
+				break
+			}
+		}
+		assert.Equal(t, uint64(70), rank)
+	})
+	t.Run("last subset", func(t *testing.T) {
+		rank, err := Rank([]int{47, 48, 49, 50, 51}, 52)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2598959), rank)
+	})
+	t.Run("unsorted indices", func(t *testing.T) {
+		_, err := Rank([]int{2, 1}, 5)
+		require.Error(t, err)
+		_, err = Rank([]int{1, 5}, 5)
+		require.Error(t, err)
+	})
+	t.Run("rank out of range", func(t *testing.T) {
+		_, err := Unrank(21, 7, 5)
+		require.Error(t, err)
+	})
+}
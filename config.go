@@ -0,0 +1,211 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/card"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/output"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+const stdioName = "-"
+
+type config struct {
+	inputDir   string
+	inputFiles []string
+	glob       string
+	outputDir  string
+	format     string
+	write      string
+	skip       string
+	categories map[int]bool
+	workers    int
+	shards     int
+	timeout    time.Duration
+	summary    string
+}
+
+// readsStdin reports whether the single input is the standard input
+func (c config) readsStdin() bool {
+	return len(c.inputFiles) == 1 && c.inputFiles[0] == stdioName
+}
+
+// writesStdout reports whether results go to the standard output instead of files
+func (c config) writesStdout() bool {
+	return c.outputDir == stdioName
+}
+
+// parseConfig reads flags and arguments of the command line.
+// Problems are reported to messages, like the flag package does
+func parseConfig(arguments []string, messages io.Writer) (config, error) {
+	c, err := parseArguments(arguments, messages)
+	if err != nil && !errors.Is(err, errFlags) {
+		_, _ = fmt.Fprintln(messages, err)
+	}
+	return c, err
+}
+
+// errFlags marks errors the flag package has already reported
+var errFlags = errors.New("invalid flags")
+
+func parseArguments(arguments []string, messages io.Writer) (config, error) {
+	flags := flag.NewFlagSet("kolesa-upgrade-homework-8", flag.ContinueOnError)
+	flags.SetOutput(messages)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(flags.Output(), "Usage: %s [flags] [files...]\n", flags.Name())
+		_, _ = fmt.Fprintf(flags.Output(), "       %s %s [flags] hands...\n\n", flags.Name(), equityCommand)
+		_, _ = fmt.Fprintln(flags.Output(), "Counts poker combinations of every input file. Files given as arguments")
+		_, _ = fmt.Fprintln(flags.Output(), "replace the input directory, - reads a single input from the standard input.")
+		flags.PrintDefaults()
+	}
+
+	inputDir := flags.String("in", "dataset", "directory with input files")
+	glob := flags.String("glob", "*", "pattern of input file names inside the input directory")
+	outputDir := flags.String("out", "results", "directory to write results to, - writes to the standard output")
+	format := flags.String("format", output.FormatText, "format of results: "+strings.Join(output.Formats(), ", "))
+	write := flags.String("write", writeOverwrite, "what to do with existing results: \"overwrite\" or \"append\"")
+	skip := flags.String("skip", "", "skip inputs whose results are up to date by \"mtime\" or by input \"hash\"")
+	categories := flags.String("categories", "", "comma separated combinations to emit, e.g. \"Pair,Full House\"; all but High Card by default")
+	includeHighCard := flags.Bool("high-card", false, "also emit hands that only make a High Card")
+	workers := flags.Int("workers", runtime.GOMAXPROCS(0), "count of files processed at the same time")
+	shards := flags.Int("shards", 1, "count of goroutines splitting the subsets of a single large file")
+	summary := flags.String("summary", "", "print counts of combinations per file and overall as a \"table\" or \"json\"")
+	timeout := flags.Duration("timeout", 0, "abandon files that are not finished in time, e.g. 10m; no limit by default")
+
+	if err := flags.Parse(arguments); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return config{}, err
+		}
+		return config{}, errFlags
+	}
+	if _, err := filepath.Match(*glob, ""); err != nil {
+		return config{}, errors.New(fmt.Sprintf("invalid glob %s: %s", *glob, err))
+	}
+
+	if _, err := output.NewEncoder(*format, io.Discard); err != nil {
+		return config{}, err
+	}
+	if *write != writeOverwrite && *write != writeAppend {
+		return config{}, errors.New(fmt.Sprintf("unsupported -write %s, use %s or %s", *write, writeOverwrite, writeAppend))
+	}
+	if *write == writeAppend && !concatenates(*format) {
+		return config{}, errors.New(fmt.Sprintf("results in %s format cannot be appended", *format))
+	}
+	if *skip != "" && *skip != skipByModificationTime && *skip != skipByHash {
+		return config{}, errors.New(fmt.Sprintf("unsupported -skip %s, use %s or %s", *skip, skipByModificationTime, skipByHash))
+	}
+	if *summary != "" && *summary != summaryTable && *summary != summaryJSON {
+		return config{}, errors.New(fmt.Sprintf("unsupported -summary %s, use %s or %s", *summary, summaryTable, summaryJSON))
+	}
+	if *workers < 1 {
+		return config{}, errors.New(fmt.Sprintf("at least one worker is required, got %d", *workers))
+	}
+
+	if *shards < 1 {
+		return config{}, errors.New(fmt.Sprintf("at least one shard is required, got %d", *shards))
+	}
+
+	parsedCategories, err := parseCategories(*categories, *includeHighCard)
+	if err != nil {
+		return config{}, err
+	}
+
+	c := config{
+		inputDir:   *inputDir,
+		inputFiles: flags.Args(),
+		glob:       *glob,
+		outputDir:  *outputDir,
+		format:     *format,
+		write:      *write,
+		skip:       *skip,
+		categories: parsedCategories,
+		workers:    *workers,
+		shards:     *shards,
+		timeout:    *timeout,
+		summary:    *summary,
+	}
+	if c.readsStdin() {
+		c.outputDir = stdioName
+	}
+	return c, nil
+}
+
+func parseCategories(categories string, includeHighCard bool) (map[int]bool, error) {
+	result := map[int]bool{}
+	if strings.TrimSpace(categories) == "" {
+		for _, rank := range card.Ranks() {
+			if rank != card.RankHighCard || includeHighCard {
+				result[rank] = true
+			}
+		}
+		return result, nil
+	}
+
+	for _, name := range strings.Split(categories, ",") {
+		category, err := card.RankOf(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+		// a category includes its subtypes, e.g. Straight Flush includes Royal Flush
+		for _, rank := range card.Ranks() {
+			if card.IsSubtypeOf(rank, category) {
+				result[rank] = true
+			}
+		}
+	}
+	if includeHighCard {
+		result[card.RankHighCard] = true
+	}
+	return result, nil
+}
+
+// concatenates reports whether results of the format stay valid when written one after another,
+// e.g. appended to a file or printed to stdout for several inputs
+func concatenates(format string) bool {
+	return format == output.FormatText || format == output.FormatJSONL
+}
+
+// inputPaths lists files to process, either the explicit ones or the matching files of the input directory.
+// Inputs writing the same result file are rejected, e.g. a/x.csv and b/x.csv, and so are several inputs
+// printed to stdout in a format that cannot be concatenated
+func inputPaths(c config) ([]string, error) {
+	paths := c.inputFiles
+	if len(paths) == 0 {
+		entries, err := os.ReadDir(c.inputDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			if matched, _ := filepath.Match(c.glob, entry.Name()); matched {
+				paths = append(paths, filepath.Join(c.inputDir, entry.Name()))
+			}
+		}
+		sort.Strings(paths)
+	}
+
+	if c.writesStdout() {
+		if len(paths) > 1 && !concatenates(c.format) {
+			return nil, errors.New(fmt.Sprintf("results of %d inputs in %s format cannot be written to stdout together", len(paths), c.format))
+		}
+		return paths, nil
+	}
+	inputs := map[string]string{}
+	for _, path := range paths {
+		result := resultPath(path, c)
+		if other, ok := inputs[result]; ok {
+			return nil, errors.New(fmt.Sprintf("inputs %s and %s would both be written to %s", other, path, result))
+		}
+		inputs[result] = path
+	}
+	return paths, nil
+}
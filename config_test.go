@@ -0,0 +1,128 @@
+package main
+
+import (
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/card"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseConfig(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		c, err := parseConfig(nil, io.Discard)
+		require.NoError(t, err)
+		assert.Equal(t, "dataset", c.inputDir)
+		assert.Equal(t, "results", c.outputDir)
+		assert.Equal(t, writeOverwrite, c.write)
+		assert.Equal(t, "", c.skip)
+		assert.False(t, c.categories[card.RankHighCard])
+		assert.True(t, c.categories[card.RankPair])
+		assert.True(t, c.categories[card.RankStraightFlush])
+		assert.True(t, c.categories[card.RankRoyalFlush])
+	})
+	t.Run("categories", func(t *testing.T) {
+		c, err := parseConfig([]string{"-categories", "pair, Full House", "-high-card"}, io.Discard)
+		require.NoError(t, err)
+		assert.Equal(t, map[int]bool{card.RankPair: true, card.RankFullHouse: true, card.RankHighCard: true}, c.categories)
+	})
+	t.Run("category includes its subtypes", func(t *testing.T) {
+		c, err := parseConfig([]string{"-categories", "Straight Flush"}, io.Discard)
+		require.NoError(t, err)
+		assert.Equal(t, map[int]bool{card.RankStraightFlush: true, card.RankRoyalFlush: true}, c.categories)
+
+		c, err = parseConfig([]string{"-categories", "Royal Flush"}, io.Discard)
+		require.NoError(t, err)
+		assert.Equal(t, map[int]bool{card.RankRoyalFlush: true}, c.categories)
+	})
+	t.Run("stdin implies stdout", func(t *testing.T) {
+		c, err := parseConfig([]string{"-"}, io.Discard)
+		require.NoError(t, err)
+		assert.True(t, c.readsStdin())
+		assert.True(t, c.writesStdout())
+	})
+	t.Run("unknown category", func(t *testing.T) {
+		_, err := parseConfig([]string{"-categories", "Royal Pair"}, io.Discard)
+		require.Error(t, err)
+	})
+	t.Run("workers and timeout", func(t *testing.T) {
+		c, err := parseConfig([]string{"-workers", "3", "-timeout", "1m30s"}, io.Discard)
+		require.NoError(t, err)
+		assert.Equal(t, 3, c.workers)
+		assert.Equal(t, 90*time.Second, c.timeout)
+
+		_, err = parseConfig([]string{"-workers", "0"}, io.Discard)
+		require.Error(t, err)
+	})
+	t.Run("write policy", func(t *testing.T) {
+		c, err := parseConfig([]string{"-write", "append", "-skip", "hash"}, io.Discard)
+		require.NoError(t, err)
+		assert.Equal(t, writeAppend, c.write)
+		assert.Equal(t, skipByHash, c.skip)
+
+		_, err = parseConfig([]string{"-write", "truncate"}, io.Discard)
+		require.Error(t, err)
+		_, err = parseConfig([]string{"-write", "append", "-format", "json"}, io.Discard)
+		require.Error(t, err)
+		_, err = parseConfig([]string{"-skip", "size"}, io.Discard)
+		require.Error(t, err)
+	})
+	t.Run("summary", func(t *testing.T) {
+		c, err := parseConfig([]string{"-summary", "json"}, io.Discard)
+		require.NoError(t, err)
+		assert.Equal(t, summaryJSON, c.summary)
+
+		_, err = parseConfig([]string{"-summary", "html"}, io.Discard)
+		require.Error(t, err)
+	})
+	t.Run("invalid glob", func(t *testing.T) {
+		_, err := parseConfig([]string{"-glob", "["}, io.Discard)
+		require.Error(t, err)
+	})
+}
+
+func TestInputPaths(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"dat2.csv", "dat1.csv", "notes.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0644))
+	}
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "nested.csv"), os.ModePerm))
+
+	t.Run("directory is filtered by glob", func(t *testing.T) {
+		paths, err := inputPaths(config{inputDir: dir, glob: "*.csv"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{filepath.Join(dir, "dat1.csv"), filepath.Join(dir, "dat2.csv")}, paths)
+	})
+	t.Run("explicit files replace directory", func(t *testing.T) {
+		paths, err := inputPaths(config{inputDir: dir, glob: "*", inputFiles: []string{"a.csv"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a.csv"}, paths)
+	})
+	t.Run("inputs with the same result", func(t *testing.T) {
+		_, err := inputPaths(config{outputDir: "results", inputFiles: []string{"a/x.csv", "b/x.csv"}})
+		require.Error(t, err)
+		_, err = inputPaths(config{outputDir: "results", format: output.FormatJSON, inputFiles: []string{"x.csv", "x.txt"}})
+		require.Error(t, err)
+
+		paths, err := inputPaths(config{outputDir: stdioName, format: output.FormatText, inputFiles: []string{"a/x.csv", "b/x.csv"}})
+		require.NoError(t, err)
+		assert.Len(t, paths, 2)
+	})
+	t.Run("several inputs to stdout", func(t *testing.T) {
+		for _, format := range output.Formats() {
+			c := config{outputDir: stdioName, format: format, inputFiles: []string{"a.csv", "b.csv"}}
+			_, err := inputPaths(c)
+			assert.Equal(t, concatenates(format), err == nil, format)
+
+			c.inputFiles = []string{"a.csv"}
+			_, err = inputPaths(c)
+			assert.NoError(t, err, format)
+		}
+		assert.True(t, concatenates(output.FormatJSONL))
+		assert.False(t, concatenates(output.FormatJSON))
+	})
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/card"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/equity"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+const equityCommand = "equity"
+
+// equityConfig is the command line of the equity subcommand
+type equityConfig struct {
+	players []equityPlayer
+	options equity.Options
+}
+
+// equityPlayer holds either the known hole cards of a player or the range of them
+type equityPlayer struct {
+	label string
+	hand  []card.Card
+	hands equity.Range
+}
+
+// parseEquityPlayer reads hole cards in any notation, e.g. "As,Kh", or a range, e.g. "QQ+,AKs"
+func parseEquityPlayer(argument string) (equityPlayer, error) {
+	hand, handErr := card.ParseList(card.NotationAuto, argument)
+	if handErr == nil && len(hand) == equity.HoleCards {
+		representations, err := card.FormatCards(hand, card.NotationShort)
+		if err != nil {
+			return equityPlayer{}, err
+		}
+		combo := equity.Combo{Cards: [equity.HoleCards]card.Card{hand[0], hand[1]}, Weight: 1}
+		return equityPlayer{label: strings.Join(representations, ","), hand: hand, hands: equity.Range{combo}}, nil
+	}
+	hands, err := equity.ParseRange(argument)
+	if err != nil {
+		if handErr != nil {
+			return equityPlayer{}, handErr
+		}
+		return equityPlayer{}, err
+	}
+	return equityPlayer{label: argument, hands: hands}, nil
+}
+
+// parseEquityConfig reads the command line of the equity subcommand, reporting problems like parseConfig
+func parseEquityConfig(arguments []string, messages io.Writer) (equityConfig, error) {
+	c, err := parseEquityArguments(arguments, messages)
+	if err != nil && !errors.Is(err, errFlags) && !errors.Is(err, flag.ErrHelp) {
+		_, _ = fmt.Fprintln(messages, err)
+	}
+	return c, err
+}
+
+func parseEquityArguments(arguments []string, messages io.Writer) (equityConfig, error) {
+	flags := flag.NewFlagSet(equityCommand, flag.ContinueOnError)
+	flags.SetOutput(messages)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(flags.Output(), "Usage: %s [flags] hands...\n\n", flags.Name())
+		_, _ = fmt.Fprintln(flags.Output(), "Calculates Texas Hold'em equity of every hand, e.g. \"As,Ah\" \"♠K,♥K\",")
+		_, _ = fmt.Fprintln(flags.Output(), "or of every range of hands, e.g. \"QQ+,AKs\" \"T9s-65s,A2s+:0.5\".")
+		flags.PrintDefaults()
+	}
+
+	board := flags.String("board", "", "comma separated community cards dealt so far")
+	dead := flags.String("dead", "", "comma separated cards out of play")
+	exhaustiveLimit := flags.Uint64("exact-limit", equity.DefaultExhaustiveLimit, "largest count of boards enumerated exactly")
+	trials := flags.Int("trials", equity.DefaultTrials, "count of random boards above the exact limit")
+	seed := flags.Int64("seed", 1, "seed of random boards")
+
+	if err := flags.Parse(arguments); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return equityConfig{}, err
+		}
+		return equityConfig{}, errFlags
+	}
+	if *trials < 1 {
+		return equityConfig{}, errors.New(fmt.Sprintf("at least one trial is required, got %d", *trials))
+	}
+
+	c := equityConfig{options: equity.Options{ExhaustiveLimit: *exhaustiveLimit, Trials: *trials, Seed: *seed}}
+	var err error
+	if c.options.Board, err = card.ParseList(card.NotationAuto, *board); err != nil {
+		return equityConfig{}, err
+	}
+	if c.options.Dead, err = card.ParseList(card.NotationAuto, *dead); err != nil {
+		return equityConfig{}, err
+	}
+	for _, argument := range flags.Args() {
+		player, err := parseEquityPlayer(argument)
+		if err != nil {
+			return equityConfig{}, err
+		}
+		c.players = append(c.players, player)
+	}
+	if len(c.players) < 2 {
+		return equityConfig{}, errors.New("hole cards of at least 2 players are required")
+	}
+	return c, nil
+}
+
+// runEquity prints the outcome of every player of the equity subcommand.
+// Known hole cards of every player are evaluated by equity.Calculate, ranges by equity.RangeEquity
+func runEquity(ctx context.Context, c equityConfig, stdout io.Writer) error {
+	var hands [][]card.Card
+	var ranges []equity.Range
+	for _, player := range c.players {
+		if player.hand != nil {
+			hands = append(hands, player.hand)
+		}
+		ranges = append(ranges, player.hands)
+	}
+
+	var result equity.Result
+	var err error
+	if len(hands) == len(c.players) {
+		result, err = equity.Calculate(ctx, hands, c.options)
+	} else {
+		result, err = equity.RangeEquity(ctx, ranges, c.options)
+	}
+	if err != nil {
+		return err
+	}
+	return writeEquity(stdout, c.players, result)
+}
+
+func writeEquity(w io.Writer, players []equityPlayer, result equity.Result) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	_, _ = fmt.Fprintln(table, "hand\twin\ttie\tequity\t")
+	for i, player := range players {
+		outcome := result.Players[i]
+		_, _ = fmt.Fprintf(table, "%s\t%.3f%%\t%.3f%%\t%.3f%%\t\n",
+			player.label, 100*outcome.Win, 100*outcome.Tie, 100*outcome.Equity)
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+	method := "showdowns of every board"
+	if !result.Exact {
+		method = "random showdowns"
+	}
+	_, err := fmt.Fprintf(w, "%d %s\n", result.Boards, method)
+	return err
+}
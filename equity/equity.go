@@ -0,0 +1,322 @@
+package equity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/card"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/combinatorics"
+	"math/rand"
+)
+
+const (
+	// HoleCards is the count of private cards of a Texas Hold'em player
+	HoleCards = 2
+	// BoardSize is the count of community cards
+	BoardSize = 5
+)
+
+const (
+	// DefaultExhaustiveLimit is large enough to enumerate every board of a preflop heads-up
+	DefaultExhaustiveLimit = 2_000_000
+	DefaultTrials          = 100_000
+)
+
+// cancellationCheck is how many boards are evaluated between checks of the context
+const cancellationCheck = 1 << 12
+
+// Outcome is how a single player fares over the evaluated boards
+type Outcome struct {
+	// Win is the probability of winning the whole pot
+	Win float64
+	// Tie is the probability of splitting the pot with other players
+	Tie float64
+	// Loss is the probability of not getting any part of the pot
+	Loss float64
+	// Equity is the expected share of the pot, a tie of n players gives each of them 1/n
+	Equity float64
+}
+
+// Result lists outcomes in the order of players
+type Result struct {
+	Players []Outcome
+	// Boards is the count of evaluated boards
+	Boards uint64
+	// Exact is set when every possible board was evaluated rather than a random sample
+	Exact bool
+}
+
+// Options describe what is known besides the hole cards of players
+type Options struct {
+	// Board holds the community cards dealt so far
+	Board []card.Card
+	// Dead cards are known to be out of play, e.g. folded or burned
+	Dead []card.Card
+	// ExhaustiveLimit is the largest count of boards enumerated exactly, DefaultExhaustiveLimit if 0
+	ExhaustiveLimit uint64
+	// Trials of the Monte Carlo method used above the limit, DefaultTrials if 0
+	Trials int
+	// Seed of the Monte Carlo method, so its results are reproducible
+	Seed int64
+}
+
+// table is a validated deal with cards encoded for evaluation
+type table struct {
+	hands []hand
+	board []card.EncodedCard
+	// remaining cards can still complete the board
+	remaining []card.EncodedCard
+	tally     *tally
+	// buffers reused between showdowns
+	strengths []int
+	cards     []card.EncodedCard
+}
+
+// hand is the encoded hole cards of a player
+type hand [HoleCards]card.EncodedCard
+
+// tally accumulates outcomes of showdowns, each of them counting with the weight of the dealt hands
+type tally struct {
+	wins   []float64
+	ties   []float64
+	shares []float64
+	weight float64
+	boards uint64
+}
+
+func newTally(players int) *tally {
+	return &tally{wins: make([]float64, players), ties: make([]float64, players), shares: make([]float64, players)}
+}
+
+func newTable(hands [][]card.Card, board []card.Card, dead []card.Card) (*table, error) {
+	if len(hands) < 2 {
+		return nil, errors.New(fmt.Sprintf("at least 2 players are required, got %d", len(hands)))
+	}
+	known := append([]card.Card(nil), dead...)
+	encoded := make([]hand, len(hands))
+	for i, holeCards := range hands {
+		if len(holeCards) != HoleCards {
+			return nil, errors.New(fmt.Sprintf("player %d must have %d hole cards, got %d", i+1, HoleCards, len(holeCards)))
+		}
+		known = append(known, holeCards...)
+		copy(encoded[i][:], encode(holeCards))
+	}
+	t, err := newBoard(len(hands), board, known)
+	if err != nil {
+		return nil, err
+	}
+	t.hands = encoded
+	return t, nil
+}
+
+// newBoard returns a table of players with the board and without the known cards in the remaining ones,
+// hands are to be dealt by the caller
+func newBoard(players int, board []card.Card, known []card.Card) (*table, error) {
+	if len(board) > BoardSize {
+		return nil, errors.New(fmt.Sprintf("board has at most %d cards, got %d", BoardSize, len(board)))
+	}
+	known = append(append([]card.Card(nil), board...), known...)
+	seen := map[card.Card]bool{}
+	for _, c := range known {
+		if _, err := c.Encode(); err != nil {
+			return nil, err
+		}
+		if seen[c] {
+			return nil, errors.New(fmt.Sprintf("card %s is dealt more than once", c))
+		}
+		seen[c] = true
+	}
+
+	deck := card.NewDeck()
+	if err := deck.Remove(known...); err != nil {
+		return nil, err
+	}
+	if missing := BoardSize - len(board); deck.Remaining() < missing {
+		return nil, errors.New(fmt.Sprintf("cannot complete the board, %d cards remaining", deck.Remaining()))
+	}
+	return &table{
+		board:     encode(board),
+		remaining: encode(deck.Cards()),
+		tally:     newTally(players),
+		strengths: make([]int, players),
+		cards:     make([]card.EncodedCard, 0, HoleCards+BoardSize),
+	}, nil
+}
+
+func encode(cards []card.Card) []card.EncodedCard {
+	encoded := make([]card.EncodedCard, len(cards))
+	for i, c := range cards {
+		encoded[i], _ = c.Encode()
+	}
+	return encoded
+}
+
+// missing is the count of board cards that are still to be dealt
+func (t *table) missing() int {
+	return BoardSize - len(t.board)
+}
+
+// completions is the count of distinct ways to complete the board out of n remaining cards
+func (t *table) completions(n int) (uint64, error) {
+	if t.missing() == 0 {
+		return 1, nil
+	}
+	return combinatorics.Count(n, t.missing())
+}
+
+// showdown evaluates every hand on the board completed with drawn cards
+func (t *table) showdown(drawn []card.EncodedCard, weight float64) {
+	cards := append(t.cards[:0], 0, 0)
+	cards = append(cards, t.board...)
+	cards = append(cards, drawn...)
+
+	best, winners := 0, 0
+	for i, holeCards := range t.hands {
+		cards[0], cards[1] = holeCards[0], holeCards[1]
+		t.strengths[i] = card.EvaluateBest(cards)
+		switch {
+		case t.strengths[i] > best:
+			best, winners = t.strengths[i], 1
+		case t.strengths[i] == best:
+			winners++
+		}
+	}
+	for i, strength := range t.strengths {
+		if strength != best {
+			continue
+		}
+		if winners == 1 {
+			t.tally.wins[i] += weight
+		} else {
+			t.tally.ties[i] += weight
+		}
+		t.tally.shares[i] += weight / float64(winners)
+	}
+	t.tally.weight += weight
+	t.tally.boards++
+}
+
+func (t *tally) result(exact bool) Result {
+	result := Result{Players: make([]Outcome, len(t.wins)), Boards: t.boards, Exact: exact}
+	if t.weight == 0 {
+		return result
+	}
+	for i := range result.Players {
+		result.Players[i] = Outcome{
+			Win:    t.wins[i] / t.weight,
+			Tie:    t.ties[i] / t.weight,
+			Loss:   (t.weight - t.wins[i] - t.ties[i]) / t.weight,
+			Equity: t.shares[i] / t.weight,
+		}
+	}
+	return result
+}
+
+// Calculate returns the equity of every Texas Hold'em hand, enumerating every board when there
+// are at most options.ExhaustiveLimit of them and sampling options.Trials boards otherwise
+func Calculate(ctx context.Context, hands [][]card.Card, options Options) (Result, error) {
+	t, err := newTable(hands, options.Board, options.Dead)
+	if err != nil {
+		return Result{}, err
+	}
+	if boards, err := t.completions(len(t.remaining)); err == nil && boards <= options.exhaustiveLimit() {
+		if err = t.exhaustive(ctx, 1); err != nil {
+			return Result{}, err
+		}
+		return t.tally.result(true), nil
+	}
+	if err = t.monteCarlo(ctx, options.trials(), rand.New(rand.NewSource(options.Seed))); err != nil {
+		return Result{}, err
+	}
+	return t.tally.result(false), nil
+}
+
+func (o Options) exhaustiveLimit() uint64 {
+	if o.ExhaustiveLimit == 0 {
+		return DefaultExhaustiveLimit
+	}
+	return o.ExhaustiveLimit
+}
+
+func (o Options) trials() int {
+	if o.Trials == 0 {
+		return DefaultTrials
+	}
+	return o.Trials
+}
+
+// Exhaustive returns the exact equity of every Texas Hold'em hand over every possible board
+func Exhaustive(ctx context.Context, hands [][]card.Card, board []card.Card, dead []card.Card) (Result, error) {
+	t, err := newTable(hands, board, dead)
+	if err != nil {
+		return Result{}, err
+	}
+	if err = t.exhaustive(ctx, 1); err != nil {
+		return Result{}, err
+	}
+	return t.tally.result(true), nil
+}
+
+// MonteCarlo estimates the equity of every Texas Hold'em hand over trials random boards.
+// The same random source always gives the same result
+func MonteCarlo(ctx context.Context, hands [][]card.Card, board []card.Card, dead []card.Card, trials int, random *rand.Rand) (Result, error) {
+	t, err := newTable(hands, board, dead)
+	if err != nil {
+		return Result{}, err
+	}
+	if err = t.monteCarlo(ctx, trials, random); err != nil {
+		return Result{}, err
+	}
+	return t.tally.result(false), nil
+}
+
+// exhaustive adds every completion of the board out of the remaining cards to the tally
+func (t *table) exhaustive(ctx context.Context, weight float64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if t.missing() == 0 {
+		t.showdown(nil, weight)
+		return nil
+	}
+
+	var cancelled error
+	err := combinatorics.EachCombination(t.remaining, t.missing(), func(drawn []card.EncodedCard) bool {
+		if t.tally.boards%cancellationCheck == 0 {
+			if cancelled = ctx.Err(); cancelled != nil {
+				return false
+			}
+		}
+		t.showdown(drawn, weight)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	return cancelled
+}
+
+func (t *table) monteCarlo(ctx context.Context, trials int, random *rand.Rand) error {
+	if trials < 1 {
+		return errors.New(fmt.Sprintf("at least one trial is required, got %d", trials))
+	}
+	deck := append([]card.EncodedCard(nil), t.remaining...)
+	for trial := 0; trial < trials; trial++ {
+		if trial%cancellationCheck == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		t.showdown(draw(deck, t.missing(), random), 1)
+	}
+	return nil
+}
+
+// draw moves n random cards to the front of the deck with a partial Fisher–Yates shuffle and returns them
+func draw(deck []card.EncodedCard, n int, random *rand.Rand) []card.EncodedCard {
+	for i := 0; i < n; i++ {
+		j := i + random.Intn(len(deck)-i)
+		deck[i], deck[j] = deck[j], deck[i]
+	}
+	return deck[:n]
+}
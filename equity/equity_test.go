@@ -0,0 +1,149 @@
+package equity
+
+import (
+	"context"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/card"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math/rand"
+	"testing"
+)
+
+func cardsOf(t *testing.T, list string) []card.Card {
+	t.Helper()
+	cards, err := card.ParseList(card.NotationAuto, list)
+	require.NoError(t, err)
+	return cards
+}
+
+func TestExhaustive(t *testing.T) {
+	t.Run("river is decided", func(t *testing.T) {
+		result, err := Exhaustive(context.Background(),
+			[][]card.Card{cardsOf(t, "As,Ks"), cardsOf(t, "Qh,Qd")},
+			cardsOf(t, "2s,7s,Qc,3d,9s"), nil)
+		require.NoError(t, err)
+		assert.True(t, result.Exact)
+		assert.Equal(t, uint64(1), result.Boards)
+		assert.Equal(t, Outcome{Win: 1, Equity: 1}, result.Players[0])
+		assert.Equal(t, Outcome{Loss: 1}, result.Players[1])
+	})
+	t.Run("flush draw on the turn", func(t *testing.T) {
+		// 9 spades are left, but ♠3 gives a full house and ♠Q four of a kind
+		result, err := Exhaustive(context.Background(),
+			[][]card.Card{cardsOf(t, "As,Ks"), cardsOf(t, "Qh,Qd")},
+			cardsOf(t, "2s,7s,Qc,3d"), nil)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(44), result.Boards)
+		assert.InDelta(t, 7.0/44, result.Players[0].Win, 1e-12)
+		assert.InDelta(t, 37.0/44, result.Players[1].Equity, 1e-12)
+	})
+	t.Run("dead cards are not dealt", func(t *testing.T) {
+		result, err := Exhaustive(context.Background(),
+			[][]card.Card{cardsOf(t, "As,Ks"), cardsOf(t, "Qh,Qd")},
+			cardsOf(t, "2s,7s,Qc,3d"), cardsOf(t, "4s,5s"))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(42), result.Boards)
+		assert.InDelta(t, 5.0/42, result.Players[0].Win, 1e-12)
+	})
+	t.Run("board plays for everyone", func(t *testing.T) {
+		result, err := Exhaustive(context.Background(),
+			[][]card.Card{cardsOf(t, "2c,3d"), cardsOf(t, "2d,3c"), cardsOf(t, "4h,2h")},
+			cardsOf(t, "As,Ks,Qs,Js,Ts"), nil)
+		require.NoError(t, err)
+		for _, outcome := range result.Players {
+			assert.Equal(t, 1.0, outcome.Tie)
+			assert.InDelta(t, 1.0/3, outcome.Equity, 1e-12)
+		}
+	})
+	t.Run("outcomes add up", func(t *testing.T) {
+		result, err := Exhaustive(context.Background(),
+			[][]card.Card{cardsOf(t, "Ah,Kh"), cardsOf(t, "Jc,Jd"), cardsOf(t, "8s,9s")},
+			cardsOf(t, "Th,Js,2c"), nil)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(903), result.Boards)
+		equity := 0.0
+		for _, outcome := range result.Players {
+			assert.InDelta(t, 1.0, outcome.Win+outcome.Tie+outcome.Loss, 1e-12)
+			equity += outcome.Equity
+		}
+		assert.InDelta(t, 1.0, equity, 1e-12)
+	})
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := Exhaustive(ctx, [][]card.Card{cardsOf(t, "As,Ks"), cardsOf(t, "Qh,Qd")}, nil, nil)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestMonteCarlo(t *testing.T) {
+	hands := [][]card.Card{cardsOf(t, "As,Ah"), cardsOf(t, "Ks,Kh")}
+
+	t.Run("same seed gives same result", func(t *testing.T) {
+		first, err := MonteCarlo(context.Background(), hands, nil, nil, 1_000, rand.New(rand.NewSource(7)))
+		require.NoError(t, err)
+		second, err := MonteCarlo(context.Background(), hands, nil, nil, 1_000, rand.New(rand.NewSource(7)))
+		require.NoError(t, err)
+		assert.Equal(t, first, second)
+		assert.False(t, first.Exact)
+		assert.Equal(t, uint64(1_000), first.Boards)
+	})
+	t.Run("close to exact equity", func(t *testing.T) {
+		board := cardsOf(t, "7d,8c")
+		exact, err := Exhaustive(context.Background(), hands, board, nil)
+		require.NoError(t, err)
+		estimated, err := MonteCarlo(context.Background(), hands, board, nil, 20_000, rand.New(rand.NewSource(1)))
+		require.NoError(t, err)
+		assert.InDelta(t, exact.Players[0].Equity, estimated.Players[0].Equity, 0.02)
+	})
+	t.Run("no trials", func(t *testing.T) {
+		_, err := MonteCarlo(context.Background(), hands, nil, nil, 0, rand.New(rand.NewSource(1)))
+		require.Error(t, err)
+	})
+}
+
+func TestCalculate(t *testing.T) {
+	hands := [][]card.Card{cardsOf(t, "As,Ah"), cardsOf(t, "Ks,Kh")}
+
+	t.Run("exact under the limit", func(t *testing.T) {
+		result, err := Calculate(context.Background(), hands, Options{Board: cardsOf(t, "2c,7d,9h")})
+		require.NoError(t, err)
+		assert.True(t, result.Exact)
+		assert.Equal(t, uint64(990), result.Boards)
+	})
+	t.Run("sampled over the limit", func(t *testing.T) {
+		result, err := Calculate(context.Background(), hands, Options{ExhaustiveLimit: 100, Trials: 500, Seed: 3})
+		require.NoError(t, err)
+		assert.False(t, result.Exact)
+		assert.Equal(t, uint64(500), result.Boards)
+		assert.Greater(t, result.Players[0].Equity, result.Players[1].Equity)
+	})
+	t.Run("invalid deals", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			hands   [][]card.Card
+			options Options
+		}{
+			"single player":     {hands: hands[:1]},
+			"three hole cards":  {hands: [][]card.Card{cardsOf(t, "As,Ah,Ad"), cardsOf(t, "Ks,Kh")}},
+			"card dealt twice":  {hands: hands, options: Options{Board: cardsOf(t, "As,2c,3c")}},
+			"dead card in hand": {hands: hands, options: Options{Dead: cardsOf(t, "Kh")}},
+			"six board cards":   {hands: hands, options: Options{Board: cardsOf(t, "2c,3c,4c,5c,6c,7c")}},
+			"joker":             {hands: [][]card.Card{{card.Joker, hands[0][0]}, hands[1]}},
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := Calculate(context.Background(), tc.hands, tc.options)
+				require.Error(t, err)
+			})
+		}
+	})
+}
+
+func BenchmarkExhaustive_preflop(b *testing.B) {
+	hands := [][]card.Card{
+		{{Suit: card.SuitSpades, Face: card.FaceAce}, {Suit: card.SuitHearts, Face: card.FaceAce}},
+		{{Suit: card.SuitSpades, Face: card.FaceKing}, {Suit: card.SuitHearts, Face: card.FaceKing}},
+	}
+	for i := 0; i < b.N; i++ {
+		_, _ = Exhaustive(context.Background(), hands, nil, nil)
+	}
+}
@@ -0,0 +1,443 @@
+package equity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/card"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxRejections is how many times in a row random combos may share cards before sampling gives up
+const maxRejections = 10_000
+
+// Combo is a concrete pair of hole cards of a range with the weight of its presence,
+// 1 for combos that are always in the range
+type Combo struct {
+	Cards  [HoleCards]card.Card
+	Weight float64
+}
+
+func newCombo(a card.Card, b card.Card, weight float64) Combo {
+	if b.Face > a.Face || b.Face == a.Face && b.Suit > a.Suit {
+		a, b = b, a
+	}
+	return Combo{Cards: [HoleCards]card.Card{a, b}, Weight: weight}
+}
+
+// Hand returns the hole cards of the combo
+func (c Combo) Hand() []card.Card {
+	return []card.Card{c.Cards[0], c.Cards[1]}
+}
+
+// Range is a weighted set of hole cards a player may hold
+type Range []Combo
+
+// ParseRange reads comma separated hands of the usual range notation:
+//
+//	AKs, AKo, AK    suited, offsuit or every combo of two faces
+//	QQ              every combo of a pair
+//	QQ+, A2s+       every higher pair, or the hand and the better kickers of its high card
+//	QQ-99, T9s-65s  hands between two ones, either pairs, or with the same high card or the same gap
+//	AsKh            a single combo
+//	AKs:0.5         any of the above with a weight in (0, 1]
+//
+// A combo listed more than once keeps the weight of its last occurrence
+func ParseRange(notation string) (Range, error) {
+	var r Range
+	indices := map[[HoleCards]card.Card]int{}
+	for _, token := range strings.Split(notation, ",") {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+		combos, err := parseRangeToken(token)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("invalid hand %q of range: %s", token, err))
+		}
+		for _, combo := range combos {
+			if index, ok := indices[combo.Cards]; ok {
+				r[index].Weight = combo.Weight
+				continue
+			}
+			indices[combo.Cards] = len(r)
+			r = append(r, combo)
+		}
+	}
+	if len(r) == 0 {
+		return nil, errors.New(fmt.Sprintf("range %q has no hands", notation))
+	}
+	return r, nil
+}
+
+// Without removes combos sharing a card with the known ones, e.g. the board or dead cards
+func (r Range) Without(known ...card.Card) Range {
+	var remaining Range
+	for _, combo := range r {
+		if !containsAny(known, combo.Cards[:]) {
+			remaining = append(remaining, combo)
+		}
+	}
+	return remaining
+}
+
+func containsAny(cards []card.Card, candidates []card.Card) bool {
+	for _, c := range cards {
+		for _, candidate := range candidates {
+			if c == candidate {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func parseRangeToken(token string) ([]Combo, error) {
+	body, weight := token, 1.0
+	if separator := strings.LastIndex(token, ":"); separator >= 0 {
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(token[separator+1:]), 64)
+		if err != nil || parsed <= 0 || parsed > 1 {
+			return nil, errors.New("weight must be a number in (0, 1]")
+		}
+		body, weight = strings.TrimSpace(token[:separator]), parsed
+	}
+
+	if len(body) == 2*HoleCards && !strings.ContainsAny(body, "+-") {
+		first, firstErr := card.Parse(card.NotationASCII, body[:2])
+		second, secondErr := card.Parse(card.NotationASCII, body[2:])
+		if firstErr == nil && secondErr == nil {
+			if *first == *second || first.IsJoker() || second.IsJoker() {
+				return nil, errors.New("hole cards must be two different cards")
+			}
+			return []Combo{newCombo(*first, *second, weight)}, nil
+		}
+	}
+
+	var classes []handClass
+	var err error
+	switch {
+	case strings.HasSuffix(body, "+"):
+		classes, err = parseOrBetter(strings.TrimSuffix(body, "+"))
+	case strings.Contains(body, "-"):
+		from, to, _ := strings.Cut(body, "-")
+		classes, err = parseBetween(from, to)
+	default:
+		var class handClass
+		class, err = parseHandClass(body)
+		classes = []handClass{class}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var combos []Combo
+	for _, class := range classes {
+		combos = append(combos, class.combos(weight)...)
+	}
+	return combos, nil
+}
+
+const (
+	suited  = 's'
+	offsuit = 'o'
+)
+
+// handClass is a hand without suits, e.g. AKs, T9o or QQ
+type handClass struct {
+	high card.Face
+	low  card.Face
+	// suitedness is suited, offsuit or 0 for both
+	suitedness byte
+}
+
+func (c handClass) isPair() bool {
+	return c.high == c.low
+}
+
+func parseHandClass(notation string) (handClass, error) {
+	if len(notation) < 2 || len(notation) > 3 {
+		return handClass{}, errors.New("hand must be two faces and optionally s or o, e.g. AKs")
+	}
+	high, highOk := card.ParseASCIIFace(notation[:1])
+	low, lowOk := card.ParseASCIIFace(notation[1:2])
+	if !highOk || !lowOk {
+		return handClass{}, errors.New(fmt.Sprintf("unrecognized faces %q", notation[:2]))
+	}
+	if low > high {
+		high, low = low, high
+	}
+	class := handClass{high: high, low: low}
+	if len(notation) == 3 {
+		class.suitedness = strings.ToLower(notation[2:])[0]
+		if class.suitedness != suited && class.suitedness != offsuit {
+			return handClass{}, errors.New(fmt.Sprintf("expected s or o after faces, got %q", notation[2:]))
+		}
+		if class.isPair() {
+			return handClass{}, errors.New("pairs cannot be suited or offsuit")
+		}
+	}
+	return class, nil
+}
+
+// parseOrBetter expands QQ+ into every higher pair and A2s+ into every higher kicker of the ace
+func parseOrBetter(notation string) ([]handClass, error) {
+	class, err := parseHandClass(notation)
+	if err != nil {
+		return nil, err
+	}
+	if class.isPair() {
+		return parseBetween(notation, strings.Repeat(card.FaceAce.String(), 2))
+	}
+	var classes []handClass
+	for kicker := class.low; kicker < class.high; kicker++ {
+		classes = append(classes, handClass{high: class.high, low: kicker, suitedness: class.suitedness})
+	}
+	return classes, nil
+}
+
+// parseBetween expands the hands between two ones of the same kind, in any order
+func parseBetween(fromNotation string, toNotation string) ([]handClass, error) {
+	from, err := parseHandClass(strings.TrimSpace(fromNotation))
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseHandClass(strings.TrimSpace(toNotation))
+	if err != nil {
+		return nil, err
+	}
+	if from.isPair() != to.isPair() || from.suitedness != to.suitedness {
+		return nil, errors.New("both ends must be pairs, or hands with the same suitedness")
+	}
+	if from.high > to.high || from.high == to.high && from.low > to.low {
+		from, to = to, from
+	}
+
+	var classes []handClass
+	switch {
+	case from.isPair():
+		for face := from.high; face <= to.high; face++ {
+			classes = append(classes, handClass{high: face, low: face})
+		}
+	case from.high == to.high:
+		for kicker := from.low; kicker <= to.low; kicker++ {
+			classes = append(classes, handClass{high: from.high, low: kicker, suitedness: from.suitedness})
+		}
+	case from.high-from.low == to.high-to.low:
+		for high := from.high; high <= to.high; high++ {
+			classes = append(classes, handClass{high: high, low: high - (from.high - from.low), suitedness: from.suitedness})
+		}
+	default:
+		return nil, errors.New("ends must share the high card or the gap between faces")
+	}
+	return classes, nil
+}
+
+// combos lists the suit combinations of the class
+func (c handClass) combos(weight float64) []Combo {
+	var combos []Combo
+	suits := card.Suits()
+	for i, first := range suits {
+		for j, second := range suits {
+			switch {
+			case c.isPair() && i >= j:
+				continue
+			case c.suitedness == suited && first != second:
+				continue
+			case c.suitedness == offsuit && first == second:
+				continue
+			}
+			combos = append(combos, newCombo(
+				card.Card{Suit: first, Face: c.high},
+				card.Card{Suit: second, Face: c.low},
+				weight,
+			))
+		}
+	}
+	return combos
+}
+
+// RangeEquity returns the equity of every range against the others. Every deal of a combo to each range
+// without shared cards counts with the product of weights of its combos. Deals and boards are enumerated
+// when there are at most options.ExhaustiveLimit showdowns, otherwise options.Trials of them are sampled
+func RangeEquity(ctx context.Context, ranges []Range, options Options) (Result, error) {
+	if len(ranges) < 2 {
+		return Result{}, errors.New(fmt.Sprintf("at least 2 players are required, got %d", len(ranges)))
+	}
+	t, err := newBoard(len(ranges), options.Board, options.Dead)
+	if err != nil {
+		return Result{}, err
+	}
+	known := append(append([]card.Card(nil), options.Board...), options.Dead...)
+	available := make([]Range, len(ranges))
+	for i, r := range ranges {
+		available[i] = r.Without(known...)
+		if len(available[i]) == 0 {
+			return Result{}, errors.New(fmt.Sprintf("range of player %d has no combos without the known cards", i+1))
+		}
+	}
+
+	dealer := newRangeDealer(t, available)
+	boards, err := t.completions(len(t.remaining) - HoleCards*len(ranges))
+	if err != nil {
+		return Result{}, err
+	}
+	if boards == 0 {
+		return Result{}, errors.New("cannot complete the board after dealing every player")
+	}
+	limit := options.exhaustiveLimit()
+	deals := dealer.countDeals(limit/boards + 1)
+	if deals == 0 {
+		return Result{}, errors.New("ranges share cards in every deal")
+	}
+	if deals*boards <= limit {
+		if err = dealer.exhaustive(ctx); err != nil {
+			return Result{}, err
+		}
+		return t.tally.result(true), nil
+	}
+	if err = dealer.monteCarlo(ctx, options.trials(), rand.New(rand.NewSource(options.Seed))); err != nil {
+		return Result{}, err
+	}
+	return t.tally.result(false), nil
+}
+
+// rangeDealer deals a combo of every range to the players of a table
+type rangeDealer struct {
+	table  *table
+	ranges []Range
+	// hands are the encoded combos of ranges
+	hands [][]hand
+	// deck is what remains after the board and the known cards
+	deck []card.EncodedCard
+	// used marks card.Card.Byte of the dealt hole cards
+	used [256]bool
+}
+
+func newRangeDealer(t *table, ranges []Range) *rangeDealer {
+	t.hands = make([]hand, len(ranges))
+	hands := make([][]hand, len(ranges))
+	for i, r := range ranges {
+		hands[i] = make([]hand, len(r))
+		for j, combo := range r {
+			copy(hands[i][j][:], encode(combo.Cards[:]))
+		}
+	}
+	return &rangeDealer{table: t, ranges: ranges, hands: hands, deck: t.remaining}
+}
+
+// eachDeal calls yield with the weight of every deal without shared cards, the hands of the table
+// are those of the deal during the call. Returning false from yield stops the iteration
+func (d *rangeDealer) eachDeal(player int, weight float64, yield func(weight float64) bool) bool {
+	if player == len(d.ranges) {
+		return yield(weight)
+	}
+	for index, combo := range d.ranges[player] {
+		first, second := combo.Cards[0].Byte(), combo.Cards[1].Byte()
+		if d.used[first] || d.used[second] {
+			continue
+		}
+		d.used[first], d.used[second] = true, true
+		d.table.hands[player] = d.hands[player][index]
+		next := d.eachDeal(player+1, weight*combo.Weight, yield)
+		d.used[first], d.used[second] = false, false
+		if !next {
+			return false
+		}
+	}
+	return true
+}
+
+// countDeals counts deals without shared cards, stopping at limit
+func (d *rangeDealer) countDeals(limit uint64) uint64 {
+	var deals uint64
+	d.eachDeal(0, 1, func(float64) bool {
+		deals++
+		return deals < limit
+	})
+	return deals
+}
+
+// remaining returns the cards of the deck that are not dealt to players
+func (d *rangeDealer) remaining(buffer []card.EncodedCard) []card.EncodedCard {
+	remaining := buffer[:0]
+	for _, encoded := range d.deck {
+		dealt := false
+		for _, holeCards := range d.table.hands {
+			if encoded == holeCards[0] || encoded == holeCards[1] {
+				dealt = true
+				break
+			}
+		}
+		if !dealt {
+			remaining = append(remaining, encoded)
+		}
+	}
+	return remaining
+}
+
+func (d *rangeDealer) exhaustive(ctx context.Context) error {
+	buffer := make([]card.EncodedCard, 0, len(d.deck))
+	var err error
+	d.eachDeal(0, 1, func(weight float64) bool {
+		d.table.remaining = d.remaining(buffer)
+		err = d.table.exhaustive(ctx, weight)
+		return err == nil
+	})
+	return err
+}
+
+func (d *rangeDealer) monteCarlo(ctx context.Context, trials int, random *rand.Rand) error {
+	if trials < 1 {
+		return errors.New(fmt.Sprintf("at least one trial is required, got %d", trials))
+	}
+	cumulative := make([][]float64, len(d.ranges))
+	for i, r := range d.ranges {
+		total := 0.0
+		for _, combo := range r {
+			total += combo.Weight
+			cumulative[i] = append(cumulative[i], total)
+		}
+	}
+
+	buffer := make([]card.EncodedCard, 0, len(d.deck))
+	for trial := 0; trial < trials; trial++ {
+		if trial%cancellationCheck == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		if !d.dealRandom(cumulative, random) {
+			return errors.New(fmt.Sprintf("ranges shared cards in %d random deals in a row", maxRejections))
+		}
+		d.table.showdown(draw(d.remaining(buffer), d.table.missing(), random), 1)
+	}
+	return nil
+}
+
+// dealRandom picks a combo of every range with the probability of its weight, until the combos share no cards
+func (d *rangeDealer) dealRandom(cumulative [][]float64, random *rand.Rand) bool {
+	for attempt := 0; attempt < maxRejections; attempt++ {
+		d.used = [256]bool{}
+		conflict := false
+		for player, weights := range cumulative {
+			index := sort.SearchFloat64s(weights, random.Float64()*weights[len(weights)-1])
+			if index == len(weights) {
+				index--
+			}
+			combo := d.ranges[player][index]
+			first, second := combo.Cards[0].Byte(), combo.Cards[1].Byte()
+			if d.used[first] || d.used[second] {
+				conflict = true
+				break
+			}
+			d.used[first], d.used[second] = true, true
+			d.table.hands[player] = d.hands[player][index]
+		}
+		if !conflict {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+package equity
+
+import (
+	"context"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/card"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func classesOf(r Range) []string {
+	var classes []string
+	seen := map[string]bool{}
+	for _, combo := range r {
+		class := combo.Cards[0].Face.String() + combo.Cards[1].Face.String()
+		if combo.Cards[0].Face != combo.Cards[1].Face {
+			if combo.Cards[0].Suit == combo.Cards[1].Suit {
+				class += "s"
+			} else {
+				class += "o"
+			}
+		}
+		if !seen[class] {
+			seen[class] = true
+			classes = append(classes, class)
+		}
+	}
+	return classes
+}
+
+func TestParseRange(t *testing.T) {
+	for notation, tc := range map[string]struct {
+		combos  int
+		classes []string
+	}{
+		"AKs":          {4, []string{"AKs"}},
+		"AKo":          {12, []string{"AKo"}},
+		"ak":           {16, nil},
+		"QQ":           {6, []string{"QQ"}},
+		"QQ+":          {18, []string{"QQ", "KK", "AA"}},
+		"99-JJ":        {18, []string{"99", "1010", "JJ"}},
+		"A2s+":         {48, nil},
+		"K9o+":         {48, []string{"K9o", "K10o", "KJo", "KQo"}},
+		"T9s-65s":      {20, []string{"65s", "76s", "87s", "98s", "109s"}},
+		"A5s-A2s":      {16, []string{"A2s", "A3s", "A4s", "A5s"}},
+		"AsKh":         {1, []string{"AKo"}},
+		"AA, AsAh:0.5": {6, []string{"AA"}},
+		"AKs,AKs":      {4, []string{"AKs"}},
+	} {
+		t.Run(notation, func(t *testing.T) {
+			r, err := ParseRange(notation)
+			require.NoError(t, err)
+			assert.Equal(t, tc.combos, len(r))
+			if tc.classes != nil {
+				assert.Equal(t, tc.classes, classesOf(r))
+			}
+		})
+	}
+
+	t.Run("weights", func(t *testing.T) {
+		r, err := ParseRange("AA:0.25, AsAh:0.5")
+		require.NoError(t, err)
+		for _, combo := range r {
+			if combo.Cards == [HoleCards]card.Card{{Suit: card.SuitSpades, Face: card.FaceAce}, {Suit: card.SuitHearts, Face: card.FaceAce}} {
+				assert.Equal(t, 0.5, combo.Weight)
+			} else {
+				assert.Equal(t, 0.25, combo.Weight)
+			}
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		for _, notation := range []string{"", "AKx", "AAs", "A", "AKQ", "AKs:0", "AKs:2", "AKs:x", "AsAs", "AKs-QQ", "AKs-AKo", "AKs-QTs", "ZZ+"} {
+			_, err := ParseRange(notation)
+			assert.Error(t, err, notation)
+		}
+	})
+}
+
+func TestRange_Without(t *testing.T) {
+	r, err := ParseRange("AA,KK")
+	require.NoError(t, err)
+	remaining := r.Without(cardsOf(t, "As,Kh,2c")...)
+	assert.Equal(t, 6, len(remaining))
+	for _, combo := range remaining {
+		assert.NotContains(t, combo.Hand(), card.Card{Suit: card.SuitSpades, Face: card.FaceAce})
+	}
+}
+
+func rangeOf(t *testing.T, notation string) Range {
+	t.Helper()
+	r, err := ParseRange(notation)
+	require.NoError(t, err)
+	return r
+}
+
+func TestRangeEquity(t *testing.T) {
+	t.Run("single combos agree with Exhaustive", func(t *testing.T) {
+		board := cardsOf(t, "2s,7s,Qc,3d")
+		expected, err := Exhaustive(context.Background(), [][]card.Card{cardsOf(t, "As,Ks"), cardsOf(t, "Qh,Qd")}, board, nil)
+		require.NoError(t, err)
+		result, err := RangeEquity(context.Background(), []Range{rangeOf(t, "AsKs"), rangeOf(t, "QhQd")}, Options{Board: board})
+		require.NoError(t, err)
+		assert.True(t, result.Exact)
+		assert.InDelta(t, expected.Players[0].Equity, result.Players[0].Equity, 1e-12)
+	})
+	t.Run("weighted average of matchups", func(t *testing.T) {
+		board := cardsOf(t, "2s,7s,Qc,3d")
+		hero := []card.Card{{Suit: card.SuitSpades, Face: card.FaceAce}, {Suit: card.SuitSpades, Face: card.FaceKing}}
+		var expected float64
+		for _, villain := range [][]card.Card{cardsOf(t, "Qh,Qd"), cardsOf(t, "Jh,Jd")} {
+			result, err := Exhaustive(context.Background(), [][]card.Card{hero, villain}, board, nil)
+			require.NoError(t, err)
+			weight := 1.0
+			if villain[0].Face == card.FaceJack {
+				weight = 0.5
+			}
+			expected += weight * result.Players[0].Equity
+		}
+		expected /= 1.5
+
+		result, err := RangeEquity(context.Background(),
+			[]Range{rangeOf(t, "AsKs"), rangeOf(t, "QhQd, JhJd:0.5")}, Options{Board: board})
+		require.NoError(t, err)
+		assert.InDelta(t, expected, result.Players[0].Equity, 1e-12)
+	})
+	t.Run("card removal", func(t *testing.T) {
+		result, err := RangeEquity(context.Background(),
+			[]Range{rangeOf(t, "AsKs"), rangeOf(t, "AA,KK")}, Options{Board: cardsOf(t, "Ah,Kh,2c,3d,4s")})
+		require.NoError(t, err)
+		// AA and KK are left with a single combo each, both making a set
+		assert.Equal(t, uint64(2), result.Boards)
+		assert.Equal(t, 1.0, result.Players[1].Win)
+	})
+	t.Run("sampled ranges are reproducible", func(t *testing.T) {
+		ranges := []Range{rangeOf(t, "QQ+,AKs"), rangeOf(t, "T9s-65s,22+")}
+		options := Options{Trials: 2_000, Seed: 11}
+		first, err := RangeEquity(context.Background(), ranges, options)
+		require.NoError(t, err)
+		second, err := RangeEquity(context.Background(), ranges, options)
+		require.NoError(t, err)
+		assert.False(t, first.Exact)
+		assert.Equal(t, first, second)
+		assert.Greater(t, first.Players[0].Equity, first.Players[1].Equity)
+	})
+	t.Run("no deal without shared cards", func(t *testing.T) {
+		_, err := RangeEquity(context.Background(), []Range{rangeOf(t, "AsKs"), rangeOf(t, "AsKh")}, Options{})
+		require.Error(t, err)
+	})
+	t.Run("range emptied by known cards", func(t *testing.T) {
+		_, err := RangeEquity(context.Background(), []Range{rangeOf(t, "AsKs"), rangeOf(t, "QhQd")}, Options{Dead: cardsOf(t, "Qh")})
+		require.Error(t, err)
+	})
+}
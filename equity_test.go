@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/equity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestParseEquityConfig(t *testing.T) {
+	t.Run("hands and board", func(t *testing.T) {
+		c, err := parseEquityConfig([]string{"-board", "2s,7s,Qc", "-dead", "♥2", "-seed", "5", "As,Ks", "♥Q,♦Q"}, io.Discard)
+		require.NoError(t, err)
+		assert.Equal(t, 2, len(c.players))
+		assert.Equal(t, 3, len(c.options.Board))
+		assert.Equal(t, 1, len(c.options.Dead))
+		assert.Equal(t, int64(5), c.options.Seed)
+		assert.Equal(t, equity.DefaultTrials, c.options.Trials)
+	})
+	t.Run("ranges", func(t *testing.T) {
+		c, err := parseEquityConfig([]string{"As,Ks", "QQ+,AKs:0.5"}, io.Discard)
+		require.NoError(t, err)
+		assert.Equal(t, "♠A,♠K", c.players[0].label)
+		assert.NotNil(t, c.players[0].hand)
+		assert.Equal(t, "QQ+,AKs:0.5", c.players[1].label)
+		assert.Nil(t, c.players[1].hand)
+		assert.Equal(t, 22, len(c.players[1].hands))
+	})
+	t.Run("single hand", func(t *testing.T) {
+		_, err := parseEquityConfig([]string{"As,Ks"}, io.Discard)
+		require.Error(t, err)
+	})
+	t.Run("invalid card", func(t *testing.T) {
+		var messages strings.Builder
+		_, err := parseEquityConfig([]string{"As,Kx", "Qh,Qd"}, &messages)
+		require.Error(t, err)
+		assert.Contains(t, messages.String(), "Kx")
+	})
+}
+
+func TestRunEquity(t *testing.T) {
+	c, err := parseEquityConfig([]string{"-board", "2s,7s,Qc,3d", "As,Ks", "Qh,Qd"}, io.Discard)
+	require.NoError(t, err)
+	var output strings.Builder
+	require.NoError(t, runEquity(context.Background(), c, &output))
+	assert.Equal(t, ""+
+		"   hand      win     tie   equity\n"+
+		"  ♠A,♠K  15.909%  0.000%  15.909%\n"+
+		"  ♥Q,♦Q  84.091%  0.000%  84.091%\n"+
+		"44 showdowns of every board\n", output.String())
+
+	c, err = parseEquityConfig([]string{"-board", "2s,7s,Qc,3d", "As,Ks", "QhQd"}, io.Discard)
+	require.NoError(t, err)
+	var ranges strings.Builder
+	require.NoError(t, runEquity(context.Background(), c, &ranges))
+	assert.Contains(t, ranges.String(), "   QhQd  84.091%")
+}
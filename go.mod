@@ -5,12 +5,11 @@ go 1.19
 require (
 	github.com/samber/lo v1.32.0
 	github.com/stretchr/testify v1.8.0
+	golang.org/x/exp v0.0.0-20220303212507-bbda1eaf7a17
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/natemcintosh/gocombinatorics v0.3.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	golang.org/x/exp v0.0.0-20220303212507-bbda1eaf7a17 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
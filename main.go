@@ -1,117 +1,255 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/card"
 	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/combinatorics"
-	"github.com/samber/lo"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/output"
+	"io"
 	"log"
 	"os"
-	"path/filepath"
+	"os/signal"
 	"strings"
-	"sync"
+	"syscall"
 	"time"
+	"unicode/utf8"
 )
 
-func processDatasetEntry(cards []card.Card) ([]card.PokerCombination, error) {
-	var result []card.PokerCombination
+// processDatasetEntry adds the combination of every subset to the summary and calls emit for
+// every combination of the selected categories, in the lexicographic order of subsets.
+// Subsets are enumerated one at a time and the cards of a combination are only valid during
+// the emit call, so large inputs are processed without holding every subset.
+// Large inputs are split into at most shards ranges evaluated concurrently
+func processDatasetEntry(
+	ctx context.Context,
+	cards []card.Card,
+	categories map[int]bool,
+	shards int,
+	summary *fileSummary,
+	emit func(card.PokerCombination) error,
+) error {
 	deduplicated := combinatorics.Deduplicate(cards)
-	combinations, err := combinatorics.Combinations(deduplicated, card.ValidCombinationSize)
+	count, err := combinatorics.Count(len(deduplicated), card.ValidCombinationSize)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	for _, comb := range combinations {
+	if count == 0 {
+		return errors.New(fmt.Sprintf("at least %d unique cards are required, got %d", card.ValidCombinationSize, len(deduplicated)))
+	}
+	ranges := shardRanges(count, shards)
+	if len(ranges) > 1 {
+		return processShards(ctx, deduplicated, categories, ranges, summary, emit)
+	}
+	return processRange(ctx, deduplicated, categories, ranges[0], summary, emit)
+}
+
+// processRange is processDatasetEntry of the subsets with ranks in the range
+func processRange(
+	ctx context.Context,
+	cards []card.Card,
+	categories map[int]bool,
+	subsets shardRange,
+	summary *fileSummary,
+	emit func(card.PokerCombination) error,
+) error {
+	var processingErr error
+	err := combinatorics.EachCombinationInRange(cards, card.ValidCombinationSize, subsets.from, subsets.to, func(comb []card.Card) bool {
+		if processingErr = ctx.Err(); processingErr != nil {
+			return false
+		}
 		combination, err := card.CombinationOf(comb)
 		if err != nil {
-			return nil, err
+			processingErr = err
+			return false
 		}
-		if combination != nil {
-			result = append(result, combination)
+		if processingErr = summary.add(combination); processingErr != nil {
+			return false
 		}
+		if categories[combination.Rank()] {
+			processingErr = emit(combination)
+		}
+		return processingErr == nil
+	})
+	if err != nil {
+		return err
 	}
-	return result, nil
+	return processingErr
 }
 
-func readCardsFromCSV(cardsCSV string) ([]card.Card, error) {
-	cards := strings.Split(cardsCSV, ",")
-	parsed := lo.Map[string, card.Card](cards, func(csvCard string, index int) card.Card {
-		parsedCard, err := card.FromShortRepresentation(csvCard)
-		if err != nil {
-			log.Fatalln(err)
+// readCardsFromCSV parses comma separated cards of every line.
+// Errors are *fileError pointing to the character of the card that could not be parsed
+func readCardsFromCSV(path string, cardsCSV string) ([]card.Card, error) {
+	var parsed []card.Card
+	for lineIndex, line := range strings.Split(cardsCSV, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
 		}
-		return *parsedCard
-	})
+		column := 0
+		for _, csvCard := range strings.Split(line, ",") {
+			parsedCard, err := card.FromShortRepresentation(csvCard)
+			if err != nil {
+				offset := 0
+				var parseError *card.ParseError
+				if errors.As(err, &parseError) {
+					offset = utf8.RuneCountInString(csvCard[:parseError.Offset])
+				}
+				return nil, &fileError{path: path, line: lineIndex + 1, column: column + offset + 1, err: err}
+			}
+			parsed = append(parsed, *parsedCard)
+			column += utf8.RuneCountInString(csvCard) + 1
+		}
+	}
 	return parsed, nil
 }
 
-func processFile(dirName, fileName, resultDirName string) {
-	inputData, err := os.ReadFile(fmt.Sprintf("%s/%s", dirName, fileName))
-	if err != nil {
-		log.Fatalln(err)
+func readInput(inputPath string) ([]byte, error) {
+	if inputPath == stdioName {
+		return io.ReadAll(os.Stdin)
 	}
+	return os.ReadFile(inputPath)
+}
 
-	inputCards, err := readCardsFromCSV(string(inputData))
+// processFile writes results of a single input and adds their summary to summaries.
+// If ctx is cancelled before the results are written, the input is abandoned and the error of ctx is returned
+func processFile(ctx context.Context, inputPath string, c config, summaries *summaryReport) error {
+	inputData, err := readInput(inputPath)
 	if err != nil {
-		log.Fatalln(err)
+		return err
 	}
-
-	result, err := processDatasetEntry(inputCards)
+	upToDate, err := isUpToDate(inputPath, inputData, c)
 	if err != nil {
-		log.Fatalln(err)
+		return err
+	}
+	if upToDate {
+		log.Printf("skipping up to date entry {%s}\n", inputPath)
+		return nil
 	}
 
-	resultFile, err := os.OpenFile(
-		fmt.Sprintf("%s/%s", resultDirName, fileName),
-		os.O_CREATE|os.O_APPEND|os.O_WRONLY,
-		0644)
-
+	inputCards, err := readCardsFromCSV(inputPath, string(inputData))
 	if err != nil {
-		log.Fatalln(err)
+		return err
 	}
 
-	lo.ForEach[card.PokerCombination](result, func(combination card.PokerCombination, index int) {
-		representation, err := combination.Representation()
-		if err != nil {
-			log.Fatalln(err)
-		}
-
-		_, err = resultFile.WriteString(fmt.Sprintf("%s\n", representation))
-		if err != nil {
-			log.Fatalln(err)
-		}
+	subsets, err := combinatorics.Count(len(combinatorics.Deduplicate(inputCards)), card.ValidCombinationSize)
+	if err != nil {
+		return &fileError{path: inputPath, err: err}
+	}
+	summary := newFileSummary(inputPath, subsets)
+	var result strings.Builder
+	encoder, err := output.NewEncoder(c.format, &result)
+	if err != nil {
+		return err
+	}
+	err = processDatasetEntry(ctx, inputCards, c.categories, c.shards, summary, func(combination card.PokerCombination) error {
+		return encoder.Encode(inputPath, combination)
 	})
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err == nil {
+		err = encoder.Close()
+	}
+	if err != nil {
+		return &fileError{path: inputPath, err: err}
+	}
 
-	_ = resultFile.Close()
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err = writeResult(inputPath, inputData, c, result.String()); err != nil {
+		return err
+	}
+	summaries.add(summary)
+	return nil
 }
 
 func main() {
-	log.Println("Starting counting combinations...")
-	files, err := os.ReadDir("dataset/")
+	if len(os.Args) > 1 && os.Args[1] == equityCommand {
+		equityMain(os.Args[2:])
+		return
+	}
 
+	c, err := parseConfig(os.Args[1:], os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
 	if err != nil {
-		log.Fatalln(err)
+		os.Exit(2)
 	}
 
-	err = os.MkdirAll(filepath.Join(".", "results"), os.ModePerm)
+	log.Println("Starting counting combinations...")
+	paths, err := inputPaths(c)
 	if err != nil {
 		log.Fatalln(err)
 	}
 
-	start := time.Now()
-	var wg sync.WaitGroup
-	for _, dirEntry := range files {
-		log.Printf("iterating dirEntry %s\n", dirEntry)
-		wg.Add(1)
+	if !c.writesStdout() {
+		err = os.MkdirAll(c.outputDir, os.ModePerm)
+		if err != nil {
+			log.Fatalln(err)
+		}
+	}
 
-		go func(entry os.DirEntry) {
-			defer wg.Done()
-			log.Printf("concurrently starting working on entry {%s}\n", entry.Name())
-			processFile("dataset", entry.Name(), "results")
-		}(dirEntry)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
 	}
-	wg.Wait()
+
+	start := time.Now()
+	var report failureReport
+	var summaries summaryReport
+	abandoned := runPool(ctx, paths, c.workers, func(ctx context.Context, path string) error {
+		log.Printf("worker starting working on entry {%s}\n", path)
+		err := processFile(ctx, path, c, &summaries)
+		if err != nil && !errors.Is(err, ctx.Err()) {
+			log.Printf("failed to process %s\n", path)
+			report.add(path, err)
+		}
+		return err
+	})
 
 	end := time.Now()
 	elapsed := end.Sub(start) //monotonic
+	if report.len() > 0 {
+		_ = report.write(os.Stderr)
+		log.Printf("%d of %d files failed", report.len(), len(paths))
+	}
+	if len(abandoned) > 0 {
+		log.Printf("Stopped after {%d}ms: %s, %d of %d files abandoned", elapsed/time.Millisecond, ctx.Err(), len(abandoned), len(paths))
+	}
+	if c.summary != "" {
+		summaryOutput := os.Stdout
+		if c.writesStdout() {
+			summaryOutput = os.Stderr
+		}
+		if err = summaries.write(summaryOutput, c.summary, c.categories); err != nil {
+			log.Println(err)
+		}
+	}
+	if report.len() > 0 || len(abandoned) > 0 {
+		os.Exit(1)
+	}
 	log.Printf("Finished in {%d}ns/{%d}ms", elapsed, elapsed/time.Millisecond)
 }
+
+func equityMain(arguments []string) {
+	c, err := parseEquityConfig(arguments, os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err = runEquity(ctx, c, os.Stdout); err != nil {
+		log.Fatalln(err)
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/card"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadCardsFromCSV(t *testing.T) {
+	t.Run("valid line with CRLF", func(t *testing.T) {
+		cards, err := readCardsFromCSV("dat.csv", "♣J,♦7,♣K\r\n")
+		require.NoError(t, err)
+		assert.Equal(t, []card.Card{
+			{Suit: card.SuitClubs, Face: card.FaceJack},
+			{Suit: card.SuitDiamonds, Face: card.Face7},
+			{Suit: card.SuitClubs, Face: card.FaceKing},
+		}, cards)
+	})
+	t.Run("invalid card points to its position", func(t *testing.T) {
+		_, err := readCardsFromCSV("dat.csv", "♣J,♦7\n♣K,♦Z\n")
+		var failure *fileError
+		require.ErrorAs(t, err, &failure)
+		assert.Equal(t, 2, failure.line)
+		assert.Equal(t, 5, failure.column)
+		var parseError *card.ParseError
+		assert.True(t, errors.As(err, &parseError))
+		assert.True(t, strings.HasPrefix(err.Error(), "dat.csv:2:5: "))
+	})
+}
+
+func TestProcessFile(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.csv")
+	invalid := filepath.Join(dir, "invalid.csv")
+	require.NoError(t, os.WriteFile(valid, []byte("♠A,♠K,♠Q,♠J,♠10,♥2\n"), 0644))
+	require.NoError(t, os.WriteFile(invalid, []byte("♠A,♠1\n"), 0644))
+
+	c, err := parseConfig([]string{"-out", filepath.Join(dir, "results"), "-categories", "Straight Flush"}, nil)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(c.outputDir, os.ModePerm))
+
+	t.Run("valid file", func(t *testing.T) {
+		require.NoError(t, processFile(context.Background(), valid, c, nil))
+		result, err := os.ReadFile(filepath.Join(c.outputDir, "valid.csv"))
+		require.NoError(t, err)
+		assert.Equal(t, "♠A,♠K,♠Q,♠J,♠10 | Royal Flush\n", string(result))
+	})
+	t.Run("invalid file is reported and not written", func(t *testing.T) {
+		err := processFile(context.Background(), invalid, c, nil)
+		var failure *fileError
+		require.ErrorAs(t, err, &failure)
+		assert.Equal(t, invalid, failure.path)
+		assert.Equal(t, 1, failure.line)
+		assert.Equal(t, 5, failure.column)
+		assert.NoFileExists(t, filepath.Join(c.outputDir, "invalid.csv"))
+	})
+	t.Run("cancelled file is abandoned", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := processFile(ctx, valid, c, nil)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestFailureReport(t *testing.T) {
+	var report failureReport
+	report.add("b.csv", &fileError{path: "b.csv", line: 1, column: 3, err: errors.New("bad card")})
+	report.add("a.csv", errors.New("cannot read"))
+	report.add("b.csv", &fileError{path: "b.csv", line: 1, column: 1, err: errors.New("bad suit")})
+
+	var output strings.Builder
+	require.NoError(t, report.write(&output))
+	assert.Equal(t, 3, report.len())
+	assert.Equal(t, "a.csv: cannot read\nb.csv:1:1: bad suit\nb.csv:1:3: bad card\n", output.String())
+}
+
+func TestProcessDatasetEntry(t *testing.T) {
+	cards, err := readCardsFromCSV("dat.csv", "♠A,♠K,♠Q,♠J,♠10,♥A,♠A")
+	require.NoError(t, err)
+	categories, err := parseCategories("", true)
+	require.NoError(t, err)
+
+	t.Run("emits every subset of unique cards", func(t *testing.T) {
+		var representations []string
+		err := processDatasetEntry(context.Background(), cards, categories, 1, nil, func(combination card.PokerCombination) error {
+			representation, err := combination.Representation()
+			representations = append(representations, representation)
+			return err
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 6, len(representations))
+		assert.Equal(t, "♠A,♠K,♠Q,♠J,♠10 | Royal Flush", representations[0])
+	})
+	t.Run("emit error stops processing", func(t *testing.T) {
+		calls := 0
+		err := processDatasetEntry(context.Background(), cards, categories, 1, nil, func(combination card.PokerCombination) error {
+			calls++
+			return errors.New("disk is full")
+		})
+		require.Error(t, err)
+		assert.Equal(t, 1, calls)
+	})
+}
@@ -0,0 +1,189 @@
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/card"
+	"io"
+)
+
+// columnarMagic starts every file of the compact columnar format:
+//
+//	magic    "PKC1"
+//	rows     uvarint
+//	sources  uvarint count, then uvarint length and bytes of every source
+//	source   rows × uvarint index into sources
+//	rank     rows × byte
+//	strength rows × little endian uint32
+//	cards    rows × card.ValidCombinationSize bytes of card.Card.Byte
+//
+// Columns are stored one after another, so a column can be read without decoding the others
+const columnarMagic = "PKC1"
+
+// ColumnarRow is a single combination read back from the columnar format
+type ColumnarRow struct {
+	Source   string
+	Rank     int
+	Strength int
+	Cards    []card.Card
+}
+
+// columnarEncoder keeps the columns in memory and writes them on Close
+type columnarEncoder struct {
+	w             io.Writer
+	sources       []string
+	sourceIndices map[string]uint64
+	source        []uint64
+	rank          []byte
+	strength      []uint32
+	cards         []byte
+}
+
+func (e *columnarEncoder) Encode(source string, combination card.PokerCombination) error {
+	if len(combination.Cards()) != card.ValidCombinationSize {
+		return errors.New(fmt.Sprintf("combination of %d cards cannot be encoded", len(combination.Cards())))
+	}
+	if e.sourceIndices == nil {
+		e.sourceIndices = map[string]uint64{}
+	}
+	index, ok := e.sourceIndices[source]
+	if !ok {
+		index = uint64(len(e.sources))
+		e.sourceIndices[source] = index
+		e.sources = append(e.sources, source)
+	}
+
+	e.source = append(e.source, index)
+	e.rank = append(e.rank, byte(combination.Rank()))
+	e.strength = append(e.strength, uint32(combination.Strength()))
+	for _, c := range combination.Cards() {
+		e.cards = append(e.cards, c.Byte())
+	}
+	return nil
+}
+
+func (e *columnarEncoder) Close() error {
+	// bufio.Writer keeps the first error and returns it from Flush
+	w := bufio.NewWriter(e.w)
+	var buffer [binary.MaxVarintLen64]byte
+	writeUvarint := func(value uint64) {
+		n := binary.PutUvarint(buffer[:], value)
+		_, _ = w.Write(buffer[:n])
+	}
+
+	_, _ = w.WriteString(columnarMagic)
+	writeUvarint(uint64(len(e.rank)))
+	writeUvarint(uint64(len(e.sources)))
+	for _, source := range e.sources {
+		writeUvarint(uint64(len(source)))
+		_, _ = w.WriteString(source)
+	}
+	for _, index := range e.source {
+		writeUvarint(index)
+	}
+	_, _ = w.Write(e.rank)
+	for _, strength := range e.strength {
+		binary.LittleEndian.PutUint32(buffer[:4], strength)
+		_, _ = w.Write(buffer[:4])
+	}
+	_, _ = w.Write(e.cards)
+	return w.Flush()
+}
+
+// maxColumnarCount bounds every count of the header, so that sizes computed from a corrupt count do not overflow
+const maxColumnarCount = 1 << 32
+
+// ReadColumnar reads every row of the columnar format.
+// Nothing is allocated from the counts of the header up front, so a corrupt file fails with an error
+func ReadColumnar(r io.Reader) ([]ColumnarRow, error) {
+	reader := bufio.NewReader(r)
+	magic := make([]byte, len(columnarMagic))
+	if _, err := io.ReadFull(reader, magic); err != nil || string(magic) != columnarMagic {
+		return nil, errors.New("not a columnar results file")
+	}
+
+	rows, err := readColumnarCount(reader, "rows")
+	if err != nil {
+		return nil, err
+	}
+	sourceCount, err := readColumnarCount(reader, "sources")
+	if err != nil {
+		return nil, err
+	}
+	var sources []string
+	for i := uint64(0); i < sourceCount; i++ {
+		length, err := readColumnarCount(reader, "source length")
+		if err != nil {
+			return nil, err
+		}
+		source, err := readColumnarBlock(reader, length)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, string(source))
+	}
+
+	var result []ColumnarRow
+	for i := uint64(0); i < rows; i++ {
+		index, err := binary.ReadUvarint(reader)
+		if err != nil {
+			return nil, err
+		}
+		if index >= sourceCount {
+			return nil, errors.New(fmt.Sprintf("row %d refers to missing source %d", i, index))
+		}
+		result = append(result, ColumnarRow{Source: sources[index]})
+	}
+	ranks, err := readColumnarBlock(reader, rows)
+	if err != nil {
+		return nil, err
+	}
+	strengths, err := readColumnarBlock(reader, 4*rows)
+	if err != nil {
+		return nil, err
+	}
+	cards, err := readColumnarBlock(reader, card.ValidCombinationSize*rows)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range result {
+		result[i].Rank = int(ranks[i])
+		result[i].Strength = int(binary.LittleEndian.Uint32(strengths[4*i:]))
+		for j := 0; j < card.ValidCombinationSize; j++ {
+			c, err := card.FromByte(cards[card.ValidCombinationSize*i+j])
+			if err != nil {
+				return nil, err
+			}
+			result[i].Cards = append(result[i].Cards, *c)
+		}
+	}
+	return result, nil
+}
+
+func readColumnarCount(reader io.ByteReader, name string) (uint64, error) {
+	count, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return 0, err
+	}
+	if count > maxColumnarCount {
+		return 0, errors.New(fmt.Sprintf("%s count %d is too large", name, count))
+	}
+	return count, nil
+}
+
+// readColumnarBlock reads n bytes into a buffer growing with the data read,
+// so a count larger than the input fails with io.ErrUnexpectedEOF
+func readColumnarBlock(reader io.Reader, n uint64) ([]byte, error) {
+	var block bytes.Buffer
+	if _, err := io.CopyN(&block, reader, int64(n)); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return block.Bytes(), nil
+}
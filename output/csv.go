@@ -0,0 +1,54 @@
+package output
+
+import (
+	"encoding/csv"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/card"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var csvHeader = []string{"source", "category", "rank", "strength", "cards"}
+
+// csvEncoder writes a header and a row per combination, cards are separated by spaces
+type csvEncoder struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+func newCSVEncoder(w io.Writer) *csvEncoder {
+	return &csvEncoder{writer: csv.NewWriter(w)}
+}
+
+func (e *csvEncoder) writeHeader() error {
+	if e.headerWritten {
+		return nil
+	}
+	e.headerWritten = true
+	return e.writer.Write(csvHeader)
+}
+
+func (e *csvEncoder) Encode(source string, combination card.PokerCombination) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	cards, err := shortRepresentations(combination)
+	if err != nil {
+		return err
+	}
+	return e.writer.Write([]string{
+		source,
+		combination.Name(),
+		strconv.Itoa(combination.Rank()),
+		strconv.Itoa(combination.Strength()),
+		strings.Join(cards, " "),
+	})
+}
+
+func (e *csvEncoder) Close() error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	e.writer.Flush()
+	return e.writer.Error()
+}
@@ -0,0 +1,69 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/card"
+	"io"
+	"strings"
+)
+
+const (
+	FormatText     = "text"
+	FormatJSON     = "json"
+	FormatJSONL    = "jsonl"
+	FormatCSV      = "csv"
+	FormatColumnar = "columnar"
+)
+
+// Encoder writes combinations found in source files.
+// Cards of a combination may be reused after Encode returns, so encoders must not keep them.
+// Close writes whatever the format needs at the end and must be called once
+type Encoder interface {
+	Encode(source string, combination card.PokerCombination) error
+	Close() error
+}
+
+// Formats returns the names of every supported format
+func Formats() []string {
+	return []string{FormatText, FormatJSON, FormatJSONL, FormatCSV, FormatColumnar}
+}
+
+// NewEncoder returns an encoder of the format writing to w
+func NewEncoder(format string, w io.Writer) (Encoder, error) {
+	switch format {
+	case FormatText:
+		return &textEncoder{w: w}, nil
+	case FormatJSON:
+		return &jsonEncoder{w: w}, nil
+	case FormatJSONL:
+		return &jsonEncoder{w: w, lines: true}, nil
+	case FormatCSV:
+		return newCSVEncoder(w), nil
+	case FormatColumnar:
+		return &columnarEncoder{w: w}, nil
+	default:
+		return nil, errors.New(fmt.Sprintf("unsupported format %s, use one of %s", format, strings.Join(Formats(), ", ")))
+	}
+}
+
+// Extension returns the file extension of results in the format.
+// Text results keep the name of the input file, so it is empty
+func Extension(format string) string {
+	switch format {
+	case FormatJSON:
+		return ".json"
+	case FormatJSONL:
+		return ".jsonl"
+	case FormatCSV:
+		return ".csv"
+	case FormatColumnar:
+		return ".pkc"
+	default:
+		return ""
+	}
+}
+
+func shortRepresentations(combination card.PokerCombination) ([]string, error) {
+	return card.FormatCards(combination.Cards(), card.NotationShort)
+}
@@ -0,0 +1,149 @@
+package output
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/card"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func combinationOf(t *testing.T, representations ...string) card.PokerCombination {
+	t.Helper()
+	cards := make([]card.Card, 0, len(representations))
+	for _, representation := range representations {
+		c, err := card.FromShortRepresentation(representation)
+		require.NoError(t, err)
+		cards = append(cards, *c)
+	}
+	combination, err := card.CombinationOf(cards)
+	require.NoError(t, err)
+	return combination
+}
+
+func encodeAll(t *testing.T, format string, combinations ...card.PokerCombination) string {
+	t.Helper()
+	var buffer bytes.Buffer
+	encoder, err := NewEncoder(format, &buffer)
+	require.NoError(t, err)
+	for _, combination := range combinations {
+		require.NoError(t, encoder.Encode("dataset/dat0.csv", combination))
+	}
+	require.NoError(t, encoder.Close())
+	return buffer.String()
+}
+
+func TestNewEncoder(t *testing.T) {
+	for _, format := range Formats() {
+		_, err := NewEncoder(format, &bytes.Buffer{})
+		require.NoError(t, err)
+	}
+	_, err := NewEncoder("xml", &bytes.Buffer{})
+	require.Error(t, err)
+}
+
+func TestExtension(t *testing.T) {
+	assert.Equal(t, "", Extension(FormatText))
+	assert.Equal(t, ".jsonl", Extension(FormatJSONL))
+	assert.Equal(t, ".pkc", Extension(FormatColumnar))
+}
+
+func TestTextEncoder(t *testing.T) {
+	pair := combinationOf(t, "♠2", "♠5", "♠A", "♠K", "♦K")
+	assert.Equal(t, "♠2,♠5,♠A,♠K,♦K | Pair\n", encodeAll(t, FormatText, pair))
+}
+
+func TestJSONEncoder(t *testing.T) {
+	pair := combinationOf(t, "♠2", "♠5", "♠A", "♠K", "♦K")
+	flush := combinationOf(t, "♠2", "♠5", "♠A", "♠K", "♠9")
+
+	t.Run("array", func(t *testing.T) {
+		var records []Record
+		require.NoError(t, json.Unmarshal([]byte(encodeAll(t, FormatJSON, pair, flush)), &records))
+		require.Equal(t, 2, len(records))
+		assert.Equal(t, Record{
+			Source:   "dataset/dat0.csv",
+			Category: card.CombinationPairName,
+			Rank:     card.RankPair,
+			Strength: pair.Strength(),
+			Kickers:  []int{13, 14, 5, 2},
+			Cards:    []string{"♠2", "♠5", "♠A", "♠K", "♦K"},
+		}, records[0])
+		assert.Equal(t, card.CombinationFlush, records[1].Category)
+	})
+	t.Run("empty array", func(t *testing.T) {
+		assert.Equal(t, "[]\n", encodeAll(t, FormatJSON))
+	})
+	t.Run("lines", func(t *testing.T) {
+		lines := strings.Split(strings.TrimSuffix(encodeAll(t, FormatJSONL, pair, flush), "\n"), "\n")
+		require.Equal(t, 2, len(lines))
+		var record Record
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &record))
+		assert.Equal(t, card.RankFlush, record.Rank)
+	})
+}
+
+func TestCSVEncoder(t *testing.T) {
+	pair := combinationOf(t, "♠2", "♠5", "♠A", "♠K", "♦K")
+	assert.Equal(t,
+		"source,category,rank,strength,cards\n"+
+			"dataset/dat0.csv,Pair,2,"+strconv.Itoa(pair.Strength())+",♠2 ♠5 ♠A ♠K ♦K\n",
+		encodeAll(t, FormatCSV, pair))
+	assert.Equal(t, "source,category,rank,strength,cards\n", encodeAll(t, FormatCSV))
+}
+
+func TestColumnarEncoder(t *testing.T) {
+	pair := combinationOf(t, "♠2", "♠5", "♠A", "♠K", "♦K")
+	flush := combinationOf(t, "♠2", "♠5", "♠A", "♠K", "♠9")
+
+	t.Run("round trip", func(t *testing.T) {
+		encoded := encodeAll(t, FormatColumnar, pair, flush)
+		rows, err := ReadColumnar(strings.NewReader(encoded))
+		require.NoError(t, err)
+		require.Equal(t, 2, len(rows))
+		assert.Equal(t, ColumnarRow{
+			Source:   "dataset/dat0.csv",
+			Rank:     card.RankPair,
+			Strength: pair.Strength(),
+			Cards:    pair.Cards(),
+		}, rows[0])
+		assert.Equal(t, flush.Cards(), rows[1].Cards)
+	})
+	t.Run("compact", func(t *testing.T) {
+		many := make([]card.PokerCombination, 100)
+		for i := range many {
+			many[i] = pair
+		}
+		assert.Less(t, len(encodeAll(t, FormatColumnar, many...)), len(encodeAll(t, FormatText, many...))/2)
+	})
+	t.Run("not columnar", func(t *testing.T) {
+		_, err := ReadColumnar(strings.NewReader("♠2,♠5,♠A,♠K,♦K | Pair\n"))
+		require.Error(t, err)
+	})
+	t.Run("truncated", func(t *testing.T) {
+		encoded := encodeAll(t, FormatColumnar, pair, flush)
+		_, err := ReadColumnar(strings.NewReader(encoded[:len(encoded)-1]))
+		require.Error(t, err)
+	})
+	t.Run("corrupt header", func(t *testing.T) {
+		uvarint := func(value uint64) string {
+			return string(binary.AppendUvarint(nil, value))
+		}
+		for name, header := range map[string]string{
+			"huge rows":          uvarint(math.MaxUint64) + uvarint(0),
+			"rows beyond input":  uvarint(1<<31) + uvarint(1) + uvarint(1) + "a",
+			"huge sources":       uvarint(1) + uvarint(math.MaxUint64),
+			"sources beyond end": uvarint(1) + uvarint(1<<31),
+			"huge source":        uvarint(1) + uvarint(1) + uvarint(math.MaxUint64),
+			"source beyond end":  uvarint(1) + uvarint(1) + uvarint(1<<31) + "a",
+		} {
+			_, err := ReadColumnar(strings.NewReader(columnarMagic + header))
+			assert.Error(t, err, name)
+		}
+	})
+}
@@ -0,0 +1,82 @@
+package output
+
+import (
+	"encoding/json"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/card"
+	"io"
+)
+
+// Record is the JSON representation of a combination
+type Record struct {
+	Source   string   `json:"source"`
+	Category string   `json:"category"`
+	Rank     int      `json:"rank"`
+	Strength int      `json:"strength"`
+	Kickers  []int    `json:"kickers"`
+	Cards    []string `json:"cards"`
+}
+
+// NewRecord describes a combination found in the source file
+func NewRecord(source string, combination card.PokerCombination) (Record, error) {
+	cards, err := shortRepresentations(combination)
+	if err != nil {
+		return Record{}, err
+	}
+	return Record{
+		Source:   source,
+		Category: combination.Name(),
+		Rank:     combination.Rank(),
+		Strength: combination.Strength(),
+		Kickers:  combination.Kickers(),
+		Cards:    cards,
+	}, nil
+}
+
+// jsonEncoder writes either a single JSON array or one JSON object per line
+type jsonEncoder struct {
+	w       io.Writer
+	lines   bool
+	started bool
+}
+
+func (e *jsonEncoder) Encode(source string, combination card.PokerCombination) error {
+	record, err := NewRecord(source, combination)
+	if err != nil {
+		return err
+	}
+	encoded, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	separator := ",\n"
+	switch {
+	case e.lines:
+		separator = ""
+	case !e.started:
+		separator = "[\n"
+	}
+	e.started = true
+	if _, err = io.WriteString(e.w, separator); err != nil {
+		return err
+	}
+	if _, err = e.w.Write(encoded); err != nil {
+		return err
+	}
+	if e.lines {
+		_, err = io.WriteString(e.w, "\n")
+	}
+	return err
+}
+
+func (e *jsonEncoder) Close() error {
+	if e.lines {
+		return nil
+	}
+	closing := "\n]\n"
+	if !e.started {
+		closing = "[]\n"
+	}
+	_, err := io.WriteString(e.w, closing)
+	return err
+}
@@ -0,0 +1,105 @@
+package output
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/card"
+	"io"
+	"strings"
+)
+
+// textSeparator separates the cards of a text result from the name of its combination
+const textSeparator = " | "
+
+// textEncoder writes the "♣J,♦7,♣K,♦9,♥7 | Pair" lines of BasicPokerCombination.Representation
+type textEncoder struct {
+	w io.Writer
+}
+
+func (e *textEncoder) Encode(source string, combination card.PokerCombination) error {
+	representation, err := combination.Representation()
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(e.w, "%s\n", representation)
+	return err
+}
+
+func (e *textEncoder) Close() error {
+	return nil
+}
+
+// LineError is a problem of a single line of results, lines are counted from 1
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// ReadText reads text results back into combinations in the order of lines.
+// The stated name of every line must be the one card.CombinationOf finds for its cards,
+// so results of other implementations can be verified. Blank lines are skipped.
+// Errors are *LineError
+func ReadText(r io.Reader) ([]card.PokerCombination, error) {
+	var combinations []card.PokerCombination
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		combination, err := ParseTextLine(scanner.Text())
+		if err != nil {
+			return nil, &LineError{Line: line, Err: err}
+		}
+		combinations = append(combinations, combination)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, &LineError{Line: line + 1, Err: err}
+	}
+	return combinations, nil
+}
+
+// ParseTextLine parses a single "♣J,♦7,♣K,♦9,♥7 | Pair" line of text results.
+// Names are matched case-insensitively, like card.RankOf does, and may name the category
+// the combination is a subtype of, e.g. Straight Flush for a Royal Flush
+func ParseTextLine(line string) (card.PokerCombination, error) {
+	representations, name, found := strings.Cut(strings.TrimSpace(line), textSeparator)
+	if !found {
+		return nil, errors.New(fmt.Sprintf("missing %q between cards and combination", strings.TrimSpace(textSeparator)))
+	}
+	statedRank, err := card.RankOf(strings.TrimSpace(name))
+	if err != nil {
+		return nil, err
+	}
+
+	var cards []card.Card
+	for _, representation := range strings.Split(representations, ",") {
+		parsed, err := card.FromShortRepresentation(representation)
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, *parsed)
+	}
+	if len(cards) != card.ValidCombinationSize {
+		return nil, errors.New(fmt.Sprintf("expected %d cards, got %d", card.ValidCombinationSize, len(cards)))
+	}
+
+	combination, err := card.CombinationOf(cards)
+	if err != nil {
+		return nil, err
+	}
+	if !card.IsSubtypeOf(combination.Rank(), statedRank) {
+		return nil, errors.New(fmt.Sprintf("cards %s make %s, not %s", strings.TrimSpace(representations), combination.Name(), strings.TrimSpace(name)))
+	}
+	return combination, nil
+}
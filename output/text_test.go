@@ -0,0 +1,62 @@
+package output
+
+import (
+	"errors"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/card"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestReadText(t *testing.T) {
+	pair := combinationOf(t, "♠2", "♠5", "♠A", "♠K", "♦K")
+	flush := combinationOf(t, "♠2", "♠5", "♠A", "♠K", "♠9")
+
+	t.Run("round trip", func(t *testing.T) {
+		combinations, err := ReadText(strings.NewReader(encodeAll(t, FormatText, pair, flush)))
+		require.NoError(t, err)
+		assert.Equal(t, []card.PokerCombination{pair, flush}, combinations)
+	})
+	t.Run("results of dat0", func(t *testing.T) {
+		file, err := os.Open("testdata/dat0.csv")
+		require.NoError(t, err)
+		defer file.Close()
+		combinations, err := ReadText(file)
+		require.NoError(t, err)
+		assert.NotEmpty(t, combinations)
+		assert.Equal(t, card.CombinationPairName, combinations[0].Name())
+	})
+	t.Run("royal flush stated as straight flush", func(t *testing.T) {
+		combinations, err := ReadText(strings.NewReader("♠A,♠K,♠Q,♠J,♠10 | Straight Flush\n"))
+		require.NoError(t, err)
+		assert.Equal(t, card.CombinationRoyalFlush, combinations[0].Name())
+	})
+	t.Run("blank lines and CRLF", func(t *testing.T) {
+		combinations, err := ReadText(strings.NewReader("\r\n♠2,♠5,♠A,♠K,♦K | pair\r\n\n"))
+		require.NoError(t, err)
+		assert.Equal(t, []card.PokerCombination{pair}, combinations)
+	})
+	t.Run("errors", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			input string
+			line  int
+		}{
+			"wrong name":     {"♠2,♠5,♠A,♠K,♦K | Pair\n♠2,♠5,♠A,♠K,♦K | Flush\n", 2},
+			"unknown name":   {"♠2,♠5,♠A,♠K,♦K | Pear\n", 1},
+			"missing name":   {"\n\n♠2,♠5,♠A,♠K,♦K\n", 3},
+			"invalid card":   {"♠2,♠5,♠X,♠K,♦K | Pair\n", 1},
+			"too few cards":  {"♠2,♠5,♠K,♦K | Pair\n", 1},
+			"too many cards": {"♠2,♠5,♠A,♠K,♦K,♦2 | Pair\n", 1},
+			"joker":          {"♠2,♠5,♠A,♠K," + card.JokerUnicode + " | Pair\n", 1},
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := ReadText(strings.NewReader(tc.input))
+				var lineError *LineError
+				require.True(t, errors.As(err, &lineError), "%v", err)
+				assert.Equal(t, tc.line, lineError.Line)
+			})
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// runPool calls process for every path using at most workers goroutines.
+// Once ctx is cancelled no new paths are started. The paths that were not started,
+// or that process abandoned by returning the error of ctx, are returned
+func runPool(ctx context.Context, paths []string, workers int, process func(ctx context.Context, path string) error) []string {
+	if workers < 1 {
+		workers = 1
+	}
+
+	queue := make(chan string)
+	go func() {
+		defer close(queue)
+		for _, path := range paths {
+			select {
+			case queue <- path:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var mutex sync.Mutex
+	finished := map[string]bool{}
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range queue {
+				err := process(ctx, path)
+				if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+					continue
+				}
+				mutex.Lock()
+				finished[path] = true
+				mutex.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	var abandoned []string
+	for _, path := range paths {
+		if !finished[path] {
+			abandoned = append(abandoned, path)
+		}
+	}
+	return abandoned
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunPool(t *testing.T) {
+	paths := make([]string, 50)
+	for i := range paths {
+		paths[i] = fmt.Sprintf("dat%d.csv", i)
+	}
+
+	t.Run("processes every path with bounded concurrency", func(t *testing.T) {
+		var running, maxRunning, processed int32
+		abandoned := runPool(context.Background(), paths, 3, func(ctx context.Context, path string) error {
+			current := atomic.AddInt32(&running, 1)
+			for {
+				observed := atomic.LoadInt32(&maxRunning)
+				if current <= observed || atomic.CompareAndSwapInt32(&maxRunning, observed, current) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			atomic.AddInt32(&processed, 1)
+			return nil
+		})
+		assert.Empty(t, abandoned)
+		assert.Equal(t, int32(len(paths)), processed)
+		assert.LessOrEqual(t, maxRunning, int32(3))
+	})
+	t.Run("cancellation abandons remaining paths", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var processed int32
+		abandoned := runPool(ctx, paths, 1, func(ctx context.Context, path string) error {
+			if atomic.AddInt32(&processed, 1) == 5 {
+				cancel()
+			}
+			return ctx.Err()
+		})
+		assert.NotEmpty(t, abandoned)
+		assert.Less(t, len(abandoned), len(paths))
+		assert.Equal(t, len(paths), len(abandoned)+4)
+	})
+	t.Run("errors other than cancellation finish the path", func(t *testing.T) {
+		abandoned := runPool(context.Background(), paths[:3], 1, func(ctx context.Context, path string) error {
+			return fmt.Errorf("broken %s", path)
+		})
+		assert.Empty(t, abandoned)
+	})
+}
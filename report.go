@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// fileError is a problem with a single input file. Line and column are 1-based, 0 when unknown
+type fileError struct {
+	path   string
+	line   int
+	column int
+	err    error
+}
+
+func (e *fileError) Error() string {
+	var position strings.Builder
+	position.WriteString(e.path)
+	if e.line > 0 {
+		position.WriteString(fmt.Sprintf(":%d", e.line))
+		if e.column > 0 {
+			position.WriteString(fmt.Sprintf(":%d", e.column))
+		}
+	}
+	return fmt.Sprintf("%s: %s", position.String(), e.err)
+}
+
+func (e *fileError) Unwrap() error {
+	return e.err
+}
+
+// failureReport collects errors of concurrently processed files
+type failureReport struct {
+	mutex    sync.Mutex
+	failures []*fileError
+}
+
+func (r *failureReport) add(path string, err error) {
+	failure, ok := err.(*fileError)
+	if !ok {
+		failure = &fileError{path: path, err: err}
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.failures = append(r.failures, failure)
+}
+
+func (r *failureReport) len() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return len(r.failures)
+}
+
+// write prints failures ordered by file, line and column
+func (r *failureReport) write(output io.Writer) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	sort.Slice(r.failures, func(i, j int) bool {
+		a, b := r.failures[i], r.failures[j]
+		if a.path != b.path {
+			return a.path < b.path
+		}
+		if a.line != b.line {
+			return a.line < b.line
+		}
+		return a.column < b.column
+	})
+	for _, failure := range r.failures {
+		if _, err := fmt.Fprintln(output, failure); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/output"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	writeOverwrite = "overwrite"
+	writeAppend    = "append"
+)
+
+const (
+	skipByModificationTime = "mtime"
+	skipByHash             = "hash"
+)
+
+// stdoutMutex keeps results of concurrently processed files from interleaving
+var stdoutMutex sync.Mutex
+
+// resultPath keeps the name of the input file, with the extension of the format unless it is text
+func resultPath(inputPath string, c config) string {
+	name := filepath.Base(inputPath)
+	if extension := output.Extension(c.format); extension != "" {
+		name = strings.TrimSuffix(name, filepath.Ext(name)) + extension
+	}
+	return filepath.Join(c.outputDir, name)
+}
+
+// hashPath is a hidden file next to the result keeping the hash of what the result was computed from.
+// It is named after the result file, so every result keeps a hash of its own
+func hashPath(inputPath string, c config) string {
+	return filepath.Join(c.outputDir, fmt.Sprintf(".%s.sha256", filepath.Base(resultPath(inputPath, c))))
+}
+
+// inputHash identifies the input together with the settings that change its result
+func inputHash(inputData []byte, c config) string {
+	hash := sha256.New()
+	hash.Write(inputData)
+	ranks := make([]int, 0, len(c.categories))
+	for rank, included := range c.categories {
+		if included {
+			ranks = append(ranks, rank)
+		}
+	}
+	sort.Ints(ranks)
+	_, _ = fmt.Fprint(hash, ranks, c.format)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// isUpToDate reports whether the result of the input does not need to be computed again
+func isUpToDate(inputPath string, inputData []byte, c config) (bool, error) {
+	if c.writesStdout() || c.readsStdin() {
+		return false, nil
+	}
+	switch c.skip {
+	case skipByModificationTime:
+		result, err := os.Stat(resultPath(inputPath, c))
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		input, err := os.Stat(inputPath)
+		if err != nil {
+			return false, err
+		}
+		return !result.ModTime().Before(input.ModTime()), nil
+	case skipByHash:
+		if _, err := os.Stat(resultPath(inputPath, c)); errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		stored, err := os.ReadFile(hashPath(inputPath, c))
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return string(stored) == inputHash(inputData, c), nil
+	default:
+		return false, nil
+	}
+}
+
+// writeResult replaces the result of the input, or appends to it with -write append.
+// The result is written to a temporary file first and renamed over the old one,
+// so an interrupted run never leaves a partial result
+func writeResult(inputPath string, inputData []byte, c config, output string) error {
+	if c.writesStdout() {
+		stdoutMutex.Lock()
+		defer stdoutMutex.Unlock()
+		_, err := io.WriteString(os.Stdout, output)
+		return err
+	}
+
+	path := resultPath(inputPath, c)
+	var previous []byte
+	if c.write == writeAppend {
+		var err error
+		previous, err = os.ReadFile(path)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	if err := writeAtomically(path, append(previous, output...)); err != nil {
+		return err
+	}
+	if c.skip == skipByHash {
+		return writeAtomically(hashPath(inputPath, c), []byte(inputHash(inputData, c)))
+	}
+	return nil
+}
+
+func writeAtomically(path string, content []byte) error {
+	temporary, err := os.CreateTemp(filepath.Dir(path), fmt.Sprintf(".%s.*.tmp", filepath.Base(path)))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temporary.Name())
+
+	if _, err = temporary.Write(content); err != nil {
+		_ = temporary.Close()
+		return err
+	}
+	if err = temporary.Sync(); err != nil {
+		_ = temporary.Close()
+		return err
+	}
+	if err = temporary.Close(); err != nil {
+		return err
+	}
+	if err = os.Chmod(temporary.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(temporary.Name(), path)
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func resultsFixture(t *testing.T, arguments ...string) (string, config) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "dat.csv")
+	require.NoError(t, os.WriteFile(input, []byte("♠A,♠K,♠Q,♠J,♠10\n"), 0644))
+
+	c, err := parseConfig(append([]string{"-out", filepath.Join(dir, "results")}, arguments...), io.Discard)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(c.outputDir, os.ModePerm))
+	return input, c
+}
+
+func readResult(t *testing.T, input string, c config) string {
+	result, err := os.ReadFile(resultPath(input, c))
+	require.NoError(t, err)
+	return string(result)
+}
+
+func TestWriteResult(t *testing.T) {
+	const line = "♠A,♠K,♠Q,♠J,♠10 | Royal Flush\n"
+
+	t.Run("reruns overwrite by default", func(t *testing.T) {
+		input, c := resultsFixture(t)
+		require.NoError(t, processFile(context.Background(), input, c, nil))
+		require.NoError(t, processFile(context.Background(), input, c, nil))
+		assert.Equal(t, line, readResult(t, input, c))
+
+		entries, err := os.ReadDir(c.outputDir)
+		require.NoError(t, err)
+		assert.Equal(t, 1, len(entries), "temporary files are removed")
+	})
+	t.Run("append keeps previous results", func(t *testing.T) {
+		input, c := resultsFixture(t, "-write", "append")
+		require.NoError(t, processFile(context.Background(), input, c, nil))
+		require.NoError(t, processFile(context.Background(), input, c, nil))
+		assert.Equal(t, line+line, readResult(t, input, c))
+	})
+	t.Run("skip by modification time", func(t *testing.T) {
+		input, c := resultsFixture(t, "-write", "append", "-skip", "mtime")
+		require.NoError(t, processFile(context.Background(), input, c, nil))
+		require.NoError(t, processFile(context.Background(), input, c, nil))
+		assert.Equal(t, line, readResult(t, input, c))
+
+		later := time.Now().Add(time.Hour)
+		require.NoError(t, os.Chtimes(input, later, later))
+		require.NoError(t, processFile(context.Background(), input, c, nil))
+		assert.Equal(t, line+line, readResult(t, input, c))
+	})
+	t.Run("skip by hash", func(t *testing.T) {
+		input, c := resultsFixture(t, "-write", "append", "-skip", "hash")
+		require.NoError(t, processFile(context.Background(), input, c, nil))
+		require.NoError(t, processFile(context.Background(), input, c, nil))
+		assert.Equal(t, line, readResult(t, input, c))
+
+		require.NoError(t, os.WriteFile(input, []byte("♠A,♠K,♠Q,♠J,♠10,♠9\n"), 0644))
+		require.NoError(t, processFile(context.Background(), input, c, nil))
+		assert.NotEqual(t, line, readResult(t, input, c))
+	})
+	t.Run("hash is kept per format", func(t *testing.T) {
+		input, text := resultsFixture(t, "-skip", "hash")
+		json := text
+		json.format = output.FormatJSON
+		assert.NotEqual(t, hashPath(input, text), hashPath(input, json))
+		assert.Equal(t, filepath.Join(text.outputDir, ".dat.json.sha256"), hashPath(input, json))
+
+		require.NoError(t, processFile(context.Background(), input, text, nil))
+		require.NoError(t, processFile(context.Background(), input, json, nil))
+		upToDate, err := isUpToDate(input, []byte("♠A,♠K,♠Q,♠J,♠10\n"), text)
+		require.NoError(t, err)
+		assert.True(t, upToDate)
+	})
+	t.Run("hash depends on categories", func(t *testing.T) {
+		_, pairs := resultsFixture(t, "-categories", "Pair")
+		_, flushes := resultsFixture(t, "-categories", "Flush")
+		data := []byte("♠A,♠K,♠Q,♠J,♠10\n")
+		assert.NotEqual(t, inputHash(data, pairs), inputHash(data, flushes))
+		assert.Equal(t, inputHash(data, pairs), inputHash(data, pairs))
+	})
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/card"
+	"sync"
+)
+
+// minShardSize keeps small inputs from being split, evaluating fewer subsets is cheaper than a goroutine
+const minShardSize = 20_000
+
+// shardRange is the range [from, to) of lexicographic subset ranks
+type shardRange struct {
+	from uint64
+	to   uint64
+}
+
+// shardRanges splits count subsets into at most shards consecutive ranges of similar size,
+// each of them at least minShardSize long unless there is a single range
+func shardRanges(count uint64, shards int) []shardRange {
+	if shards < 1 {
+		shards = 1
+	}
+	if limit := count / minShardSize; uint64(shards) > limit {
+		shards = int(limit)
+		if shards < 1 {
+			shards = 1
+		}
+	}
+
+	ranges := make([]shardRange, 0, shards)
+	size, remainder := count/uint64(shards), count%uint64(shards)
+	from := uint64(0)
+	for i := 0; i < shards; i++ {
+		to := from + size
+		if uint64(i) < remainder {
+			to++
+		}
+		ranges = append(ranges, shardRange{from: from, to: to})
+		from = to
+	}
+	return ranges
+}
+
+// shardOutput holds combinations of a shard until every previous shard is emitted,
+// then its combinations are passed to emit as they are produced
+type shardOutput struct {
+	mutex    sync.Mutex
+	direct   bool
+	buffered []card.PokerCombination
+}
+
+func (o *shardOutput) add(combination card.PokerCombination, emit func(card.PokerCombination) error) error {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if o.direct {
+		return emit(combination)
+	}
+	// cards of the combination are reused by the enumeration, keep a copy
+	kept, err := card.Detach(combination)
+	if err != nil {
+		return err
+	}
+	o.buffered = append(o.buffered, kept)
+	return nil
+}
+
+// stream emits the held combinations and lets the following ones through directly
+func (o *shardOutput) stream(emit func(card.PokerCombination) error) error {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	for _, combination := range o.buffered {
+		if err := emit(combination); err != nil {
+			return err
+		}
+	}
+	o.buffered, o.direct = nil, true
+	return nil
+}
+
+// processShards evaluates every range concurrently and emits the results in the order of ranges,
+// so the output does not depend on how the subsets were split. The first unfinished shard emits
+// directly, only the shards ahead of it hold their results, and emit is never called concurrently.
+// Every shard has a summary of its own, they are merged into the summary in the order of ranges
+func processShards(
+	ctx context.Context,
+	cards []card.Card,
+	categories map[int]bool,
+	ranges []shardRange,
+	summary *fileSummary,
+	emit func(card.PokerCombination) error,
+) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	outputs := make([]*shardOutput, len(ranges))
+	summaries := make([]*fileSummary, len(ranges))
+	done := make([]chan struct{}, len(ranges))
+	for i := range ranges {
+		outputs[i], done[i] = &shardOutput{direct: i == 0}, make(chan struct{})
+		if summary != nil {
+			summaries[i] = newFileSummary(summary.source, 0)
+		}
+	}
+	errs := make([]error, len(ranges))
+	for i, subsets := range ranges {
+		go func(i int, subsets shardRange) {
+			defer close(done[i])
+			errs[i] = processRange(ctx, cards, categories, subsets, summaries[i], func(combination card.PokerCombination) error {
+				return outputs[i].add(combination, emit)
+			})
+			if errs[i] != nil {
+				cancel()
+			}
+		}(i, subsets)
+	}
+
+	var emitErr error
+	for i := range ranges {
+		<-done[i]
+		if i+1 < len(ranges) && ctx.Err() == nil {
+			if emitErr = outputs[i+1].stream(emit); emitErr != nil {
+				cancel()
+			}
+		}
+	}
+
+	for _, err := range errs {
+		if err != nil && !errors.Is(err, context.Canceled) {
+			return err
+		}
+	}
+	if emitErr != nil {
+		return emitErr
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	for _, shardSummary := range summaries {
+		summary.merge(shardSummary)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/card"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync/atomic"
+	"testing"
+)
+
+func TestShardRanges(t *testing.T) {
+	t.Run("small input is not split", func(t *testing.T) {
+		assert.Equal(t, []shardRange{{0, 6188}}, shardRanges(6188, 8))
+	})
+	t.Run("ranges cover everything", func(t *testing.T) {
+		ranges := shardRanges(10*minShardSize+3, 4)
+		require.Equal(t, 4, len(ranges))
+		assert.Equal(t, uint64(0), ranges[0].from)
+		for i := 1; i < len(ranges); i++ {
+			assert.Equal(t, ranges[i-1].to, ranges[i].from)
+		}
+		assert.Equal(t, uint64(10*minShardSize+3), ranges[len(ranges)-1].to)
+	})
+	t.Run("shards are limited by minimal size", func(t *testing.T) {
+		assert.Equal(t, 2, len(shardRanges(2*minShardSize+1, 16)))
+	})
+}
+
+func TestProcessShards(t *testing.T) {
+	deck := card.NewDeck().Cards()[:26]
+	categories, err := parseCategories("", true)
+	require.NoError(t, err)
+
+	collect := func(shards int) []string {
+		var representations []string
+		err := processDatasetEntry(context.Background(), deck, categories, shards, nil, func(combination card.PokerCombination) error {
+			representation, err := combination.Representation()
+			representations = append(representations, representation)
+			return err
+		})
+		require.NoError(t, err)
+		return representations
+	}
+
+	sequential := collect(1)
+	sharded := collect(4)
+	assert.Equal(t, 65780, len(sequential))
+	assert.Equal(t, sequential, sharded)
+
+	t.Run("summary does not depend on shards", func(t *testing.T) {
+		pairs := map[int]bool{card.RankPair: true}
+		summarize := func(shards int) (*fileSummary, int) {
+			summary := newFileSummary("dat.csv", 0)
+			emitted := 0
+			err := processDatasetEntry(context.Background(), deck, pairs, shards, summary, func(combination card.PokerCombination) error {
+				assert.Equal(t, card.RankPair, combination.Rank())
+				emitted++
+				return nil
+			})
+			require.NoError(t, err)
+			return summary, emitted
+		}
+		sequential, sequentialEmitted := summarize(1)
+		sharded, shardedEmitted := summarize(4)
+		assert.Equal(t, sequential.counts, sharded.counts)
+		assert.Equal(t, sequentialEmitted, shardedEmitted)
+		assert.Equal(t, sequential.counts[card.RankPair], shardedEmitted)
+		assert.Equal(t, card.RankRoyalFlush, sharded.strongest.Rank())
+		assert.Equal(t, sequential.strongest, sharded.strongest)
+	})
+	t.Run("emit is not called concurrently", func(t *testing.T) {
+		var active, overlaps int32
+		err := processDatasetEntry(context.Background(), deck, categories, 4, nil, func(combination card.PokerCombination) error {
+			if atomic.AddInt32(&active, 1) > 1 {
+				atomic.AddInt32(&overlaps, 1)
+			}
+			defer atomic.AddInt32(&active, -1)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Zero(t, atomic.LoadInt32(&overlaps))
+	})
+
+	t.Run("error of emit is returned", func(t *testing.T) {
+		err := processDatasetEntry(context.Background(), deck, categories, 4, nil, func(combination card.PokerCombination) error {
+			return errors.New("disk is full")
+		})
+		require.Error(t, err)
+	})
+	t.Run("cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := processDatasetEntry(ctx, deck, categories, 4, nil, func(combination card.PokerCombination) error {
+			return nil
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
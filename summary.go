@@ -0,0 +1,196 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/card"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/output"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+)
+
+const (
+	summaryTable = "table"
+	summaryJSON  = "json"
+)
+
+// totalSummaryPath names the summary of the whole run among summaries of files
+const totalSummaryPath = "total"
+
+// fileSummary counts combinations of every category among the subsets of a single input
+// and keeps the strongest of them, whether or not its category is selected
+type fileSummary struct {
+	subsets   uint64
+	counts    map[int]int
+	strongest card.PokerCombination
+	source    string
+}
+
+func newFileSummary(source string, subsets uint64) *fileSummary {
+	return &fileSummary{source: source, subsets: subsets, counts: map[int]int{}}
+}
+
+// add counts a combination, a nil summary counts nothing. Cards of the combination may be reused after add returns
+func (s *fileSummary) add(combination card.PokerCombination) error {
+	if s == nil {
+		return nil
+	}
+	s.counts[combination.Rank()]++
+	if card.Compare(combination, s.strongest) <= 0 {
+		return nil
+	}
+	kept, err := card.Detach(combination)
+	if err != nil {
+		return err
+	}
+	s.strongest = kept
+	return nil
+}
+
+// merge adds the counts of another summary, the first of equally strong hands is kept.
+// Merging into or from a nil summary does nothing
+func (s *fileSummary) merge(other *fileSummary) {
+	if s == nil || other == nil {
+		return
+	}
+	s.subsets += other.subsets
+	for rank, count := range other.counts {
+		s.counts[rank] += count
+	}
+	if card.Compare(other.strongest, s.strongest) > 0 {
+		s.strongest = other.strongest
+		s.source = other.source
+	}
+}
+
+// summaryReport collects summaries of concurrently processed files.
+// A nil report collects nothing
+type summaryReport struct {
+	mutex sync.Mutex
+	files []*fileSummary
+}
+
+func (r *summaryReport) add(summary *fileSummary) {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.files = append(r.files, summary)
+}
+
+// total merges summaries of every file, files are sorted by path
+func (r *summaryReport) total() (*fileSummary, []*fileSummary) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	sort.Slice(r.files, func(i, j int) bool {
+		return r.files[i].source < r.files[j].source
+	})
+	total := newFileSummary("", 0)
+	for _, summary := range r.files {
+		total.merge(summary)
+	}
+	return total, r.files
+}
+
+// categoryRecord compares the share of a category among subsets with its probability in a standard deck
+type categoryRecord struct {
+	Category        string  `json:"category"`
+	Count           int     `json:"count"`
+	Percent         float64 `json:"percent"`
+	ExpectedPercent float64 `json:"expected_percent"`
+}
+
+type summaryRecord struct {
+	Path       string           `json:"path"`
+	Subsets    uint64           `json:"subsets"`
+	Categories []categoryRecord `json:"categories"`
+	Strongest  *output.Record   `json:"strongest"`
+}
+
+func (s *fileSummary) record(path string, categories map[int]bool) (summaryRecord, error) {
+	result := summaryRecord{Path: path, Subsets: s.subsets, Categories: []categoryRecord{}}
+	for _, rank := range card.Ranks() {
+		if !categories[rank] {
+			continue
+		}
+		percent := 0.0
+		if s.subsets > 0 {
+			percent = 100 * float64(s.counts[rank]) / float64(s.subsets)
+		}
+		result.Categories = append(result.Categories, categoryRecord{
+			Category:        card.NameOf(rank),
+			Count:           s.counts[rank],
+			Percent:         percent,
+			ExpectedPercent: 100 * card.Probability(rank),
+		})
+	}
+	if s.strongest != nil {
+		record, err := output.NewRecord(s.source, s.strongest)
+		if err != nil {
+			return summaryRecord{}, err
+		}
+		result.Strongest = &record
+	}
+	return result, nil
+}
+
+// write prints counts of the selected categories of every file and of the whole run
+func (r *summaryReport) write(w io.Writer, format string, categories map[int]bool) error {
+	total, files := r.total()
+	var summaries []summaryRecord
+	for _, summary := range files {
+		described, err := summary.record(summary.source, categories)
+		if err != nil {
+			return err
+		}
+		summaries = append(summaries, described)
+	}
+	described, err := total.record(totalSummaryPath, categories)
+	if err != nil {
+		return err
+	}
+	summaries = append(summaries, described)
+
+	if format == summaryJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(struct {
+			Files []summaryRecord `json:"files"`
+			Total summaryRecord   `json:"total"`
+		}{Files: summaries[:len(summaries)-1], Total: summaries[len(summaries)-1]})
+	}
+	return writeSummaryTable(w, summaries)
+}
+
+func writeSummaryTable(w io.Writer, summaries []summaryRecord) error {
+	for _, summary := range summaries {
+		strongest := "none"
+		if summary.Strongest != nil {
+			strongest = fmt.Sprintf("%s %s", strings.Join(summary.Strongest.Cards, ","), summary.Strongest.Category)
+			if summary.Path == totalSummaryPath {
+				strongest += fmt.Sprintf(" in %s", summary.Strongest.Source)
+			}
+		}
+		if _, err := fmt.Fprintf(w, "%s: %d subsets, strongest %s\n", summary.Path, summary.Subsets, strongest); err != nil {
+			return err
+		}
+
+		table := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+		_, _ = fmt.Fprintln(table, "category\tcount\tpercent\texpected\t")
+		for _, category := range summary.Categories {
+			_, _ = fmt.Fprintf(table, "%s\t%d\t%.4f%%\t%.4f%%\t\n",
+				category.Category, category.Count, category.Percent, category.ExpectedPercent)
+		}
+		if err := table.Flush(); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
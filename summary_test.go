@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/card"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSummaryReport(t *testing.T) {
+	dir := t.TempDir()
+	royal := filepath.Join(dir, "royal.csv")
+	pairs := filepath.Join(dir, "pairs.csv")
+	require.NoError(t, os.WriteFile(royal, []byte("♠A,♠K,♠Q,♠J,♠10,♥2\n"), 0644))
+	require.NoError(t, os.WriteFile(pairs, []byte("♠2,♥2,♠5,♥5,♦9,♣J\n"), 0644))
+
+	c, err := parseConfig([]string{"-out", filepath.Join(dir, "results"), "-summary", "json"}, io.Discard)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(c.outputDir, os.ModePerm))
+
+	var summaries summaryReport
+	require.NoError(t, processFile(context.Background(), royal, c, &summaries))
+	require.NoError(t, processFile(context.Background(), pairs, c, &summaries))
+
+	t.Run("json", func(t *testing.T) {
+		var output strings.Builder
+		require.NoError(t, summaries.write(&output, summaryJSON, c.categories))
+		var report struct {
+			Files []summaryRecord `json:"files"`
+			Total summaryRecord   `json:"total"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(output.String()), &report))
+
+		require.Equal(t, 2, len(report.Files))
+		assert.Equal(t, pairs, report.Files[0].Path)
+		assert.Equal(t, royal, report.Files[1].Path)
+		assert.Equal(t, card.CombinationTwoPairs, report.Files[0].Strongest.Category)
+
+		total := report.Total
+		assert.Equal(t, totalSummaryPath, total.Path)
+		assert.Equal(t, uint64(12), total.Subsets)
+		assert.Equal(t, card.CombinationRoyalFlush, total.Strongest.Category)
+		assert.Equal(t, royal, total.Strongest.Source)
+		assert.Equal(t, []string{"♠A", "♠K", "♠Q", "♠J", "♠10"}, total.Strongest.Cards)
+
+		counts := map[string]int{}
+		for _, category := range total.Categories {
+			counts[category.Category] = category.Count
+			rank, err := card.RankOf(category.Category)
+			require.NoError(t, err)
+			assert.InDelta(t, 100*card.Probability(rank), category.ExpectedPercent, 1e-9)
+		}
+		assert.Equal(t, 1, counts[card.CombinationRoyalFlush])
+		assert.Equal(t, 0, counts[card.CombinationStraightFlush])
+		assert.Equal(t, 0, counts[card.CombinationStraight])
+		assert.Equal(t, 2, counts[card.CombinationTwoPairs])
+		assert.Equal(t, 4, counts[card.CombinationPairName])
+		assert.NotContains(t, counts, card.CombinationHighCard)
+	})
+	t.Run("strongest hand outside the categories", func(t *testing.T) {
+		highCards := filepath.Join(dir, "high.csv")
+		require.NoError(t, os.WriteFile(highCards, []byte("♠2,♥4,♠6,♥8,♦10,♣Q\n"), 0644))
+		var report summaryReport
+		require.NoError(t, processFile(context.Background(), highCards, c, &report))
+		total, _ := report.total()
+		require.NotNil(t, total.strongest)
+		assert.Equal(t, card.RankHighCard, total.strongest.Rank())
+		assert.Equal(t, []int{12, 10, 8, 6, 4}, total.strongest.Kickers())
+	})
+	t.Run("table", func(t *testing.T) {
+		var output strings.Builder
+		require.NoError(t, summaries.write(&output, summaryTable, c.categories))
+		assert.Contains(t, output.String(), "total: 12 subsets, strongest ♠A,♠K,♠Q,♠J,♠10 Royal Flush in "+royal)
+	})
+}
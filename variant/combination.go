@@ -0,0 +1,88 @@
+package variant
+
+import (
+	"fmt"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/card"
+	"sort"
+	"strings"
+)
+
+// kickerBits is the width of a single kicker inside the strength, like in card.BasicPokerCombination
+const kickerBits = 4
+
+// combination is a hand ranked by the rules of a variant. Its rank names the category
+// of the hand, while the order of categories is up to the variant and only kept in strength
+type combination struct {
+	rank     int
+	kickers  []int
+	strength int
+	cards    []card.Card
+}
+
+// newCombination orders hands by order first and then by kickers
+func newCombination(cards []card.Card, rank int, order int, kickers []int) combination {
+	return combination{rank: rank, kickers: kickers, strength: strengthOf(order, kickers), cards: cards}
+}
+
+func strengthOf(order int, kickers []int) int {
+	strength := order
+	for i := 0; i < card.ValidCombinationSize; i++ {
+		strength <<= kickerBits
+		if i < len(kickers) {
+			strength |= kickers[i]
+		}
+	}
+	return strength
+}
+
+func (c combination) Name() string {
+	return card.NameOf(c.rank)
+}
+
+func (c combination) Cards() []card.Card {
+	return c.cards
+}
+
+func (c combination) Rank() int {
+	return c.rank
+}
+
+func (c combination) Kickers() []int {
+	return c.kickers
+}
+
+func (c combination) Strength() int {
+	return c.strength
+}
+
+func (c combination) Representation() (string, error) {
+	representations, err := card.FormatCards(c.cards, card.NotationShort)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s | %s", strings.Join(representations, ","), c.Name()), nil
+}
+
+// groupFaces orders face values by how often they occur and then by value, like the kickers
+// of card.PokerCombination, and returns the counts of faces in the same order
+func groupFaces(values []int) ([]int, []int) {
+	counts := map[int]int{}
+	for _, value := range values {
+		counts[value]++
+	}
+	var kickers []int
+	for value := range counts {
+		kickers = append(kickers, value)
+	}
+	sort.Slice(kickers, func(i, j int) bool {
+		if counts[kickers[i]] != counts[kickers[j]] {
+			return counts[kickers[i]] > counts[kickers[j]]
+		}
+		return kickers[i] > kickers[j]
+	})
+	groups := make([]int, len(kickers))
+	for i, kicker := range kickers {
+		groups[i] = counts[kicker]
+	}
+	return groups, kickers
+}
@@ -0,0 +1,168 @@
+package variant
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/card"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/combinatorics"
+)
+
+// holdem is standard high poker: a 52-card deck and the best five of hole and board cards
+type holdem struct{}
+
+func (holdem) Name() string {
+	return "holdem"
+}
+
+func (holdem) Deck() *card.Deck {
+	return card.NewDeck()
+}
+
+func (holdem) HoleCards() int {
+	return 2
+}
+
+func (holdem) Evaluate(hand []card.Card) (card.PokerCombination, error) {
+	if err := validateHand(hand, card.Face2); err != nil {
+		return nil, err
+	}
+	return card.CombinationOf(hand)
+}
+
+func (h holdem) Best(hole []card.Card, board []card.Card) (card.PokerCombination, error) {
+	return bestOfAny(h, hole, board)
+}
+
+// shortDeckLowest is the lowest face of the 36-card short deck
+const shortDeckLowest = card.Face6
+
+// shortDeckOrder ranks a flush above a full house, as it is harder to make without the low cards
+var shortDeckOrder = map[int]int{
+	card.RankHighCard:      1,
+	card.RankPair:          2,
+	card.RankTwoPairs:      3,
+	card.RankThreeOfAKind:  4,
+	card.RankStraight:      5,
+	card.RankFullHouse:     6,
+	card.RankFlush:         7,
+	card.RankFourOfAKind:   8,
+	card.RankStraightFlush: 9,
+	card.RankRoyalFlush:    10,
+}
+
+// shortDeck is Short Deck hold'em, also known as 6+: the cards from 2 to 5 are removed,
+// a flush beats a full house and the ace plays low in the A-6-7-8-9 straight
+type shortDeck struct{}
+
+func (shortDeck) Name() string {
+	return "short-deck"
+}
+
+func (shortDeck) Deck() *card.Deck {
+	deck := card.NewDeck()
+	var removed []card.Card
+	for _, c := range deck.Cards() {
+		if c.Face < shortDeckLowest {
+			removed = append(removed, c)
+		}
+	}
+	_ = deck.Remove(removed...)
+	return deck
+}
+
+func (shortDeck) HoleCards() int {
+	return 2
+}
+
+func (shortDeck) Evaluate(hand []card.Card) (card.PokerCombination, error) {
+	if err := validateHand(hand, shortDeckLowest); err != nil {
+		return nil, err
+	}
+	standard, err := card.CombinationOf(hand)
+	if err != nil {
+		return nil, err
+	}
+	rank, kickers := standard.Rank(), standard.Kickers()
+	if isShortDeckWheel(hand) {
+		rank, kickers = card.RankStraight, []int{int(card.Face9)}
+		if standard.Rank() == card.RankFlush {
+			rank = card.RankStraightFlush
+		}
+	}
+	order, err := orderOf(shortDeckOrder, rank)
+	if err != nil {
+		return nil, err
+	}
+	return newCombination(hand, rank, order, kickers), nil
+}
+
+// isShortDeckWheel reports whether the hand is A-6-7-8-9, the lowest straight of the short deck
+func isShortDeckWheel(hand []card.Card) bool {
+	faces := map[card.Face]bool{}
+	for _, c := range hand {
+		faces[c.Face] = true
+	}
+	return len(faces) == card.ValidCombinationSize &&
+		faces[card.FaceAce] && faces[card.Face6] && faces[card.Face7] && faces[card.Face8] && faces[card.Face9]
+}
+
+func (s shortDeck) Best(hole []card.Card, board []card.Card) (card.PokerCombination, error) {
+	return bestOfAny(s, hole, board)
+}
+
+const (
+	omahaHoleCards  = 2
+	omahaBoardCards = card.ValidCombinationSize - omahaHoleCards
+)
+
+// omaha is ranked like hold'em, but a hand is made of exactly two of the four hole cards
+// and exactly three board cards
+type omaha struct{}
+
+func (omaha) Name() string {
+	return "omaha"
+}
+
+func (omaha) Deck() *card.Deck {
+	return card.NewDeck()
+}
+
+func (omaha) HoleCards() int {
+	return 4
+}
+
+func (omaha) Evaluate(hand []card.Card) (card.PokerCombination, error) {
+	return Holdem.Evaluate(hand)
+}
+
+func (o omaha) Best(hole []card.Card, board []card.Card) (card.PokerCombination, error) {
+	each, err := omahaHands(hole, board)
+	if err != nil {
+		return nil, err
+	}
+	return bestOf(o.Evaluate, each)
+}
+
+// omahaHands makes hands of exactly two hole cards and exactly three board cards
+func omahaHands(hole []card.Card, board []card.Card) (hands, error) {
+	if len(hole) < omahaHoleCards || len(board) < omahaBoardCards {
+		return nil, errors.New(fmt.Sprintf("omaha needs at least %d hole and %d board cards, got %d and %d",
+			omahaHoleCards, omahaBoardCards, len(hole), len(board)))
+	}
+	return func(yield func(hand []card.Card) bool) error {
+		var innerErr error
+		err := combinatorics.EachCombination(hole, omahaHoleCards, func(holeCards []card.Card) bool {
+			proceed := true
+			innerErr = combinatorics.EachCombination(board, omahaBoardCards, func(boardCards []card.Card) bool {
+				hand := append(append(make([]card.Card, 0, card.ValidCombinationSize), holeCards...), boardCards...)
+				proceed = yield(hand)
+				return proceed
+			})
+			return proceed && innerErr == nil
+		})
+		if err != nil {
+			return err
+		}
+		return innerErr
+	}, nil
+}
@@ -0,0 +1,173 @@
+package variant
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/card"
+)
+
+// LowQualifier is the highest face of a qualifying low hand in eight-or-better split games
+const LowQualifier = card.Face8
+
+// studHoleCards is the count of cards of a seven card stud player, there is no board
+const studHoleCards = 7
+
+// Split is the best high and the best low hand of a player in a split pot game
+type Split struct {
+	High card.PokerCombination
+	// Low is nil when no hand of the player qualifies for low
+	Low card.PokerCombination
+}
+
+// SplitRules are rules of a split pot game: the best high hand wins half of the pot and the best
+// qualifying low hand wins the other half
+type SplitRules interface {
+	Name() string
+	// Deck returns a new deck of the variant in a fixed order
+	Deck() *card.Deck
+	// HoleCards is the count of private cards of a player
+	HoleCards() int
+	// BestSplit returns the best high and the best low hand a player makes out of the hole cards and the board,
+	// the two may be made of different cards
+	BestSplit(hole []card.Card, board []card.Card) (Split, error)
+}
+
+var (
+	// OmahaHiLo is Omaha eight-or-better, both hands are made of exactly two hole cards and three board cards
+	OmahaHiLo SplitRules = hiLo{name: "omaha-hi-lo", holeCards: 4, hands: omahaHands}
+	// StudHiLo is seven card stud eight-or-better, both hands are made of any five of the seven cards
+	StudHiLo SplitRules = hiLo{name: "stud-hi-lo", holeCards: studHoleCards, hands: func(hole []card.Card, board []card.Card) (hands, error) {
+		return anyFive(append(append([]card.Card(nil), hole...), board...))
+	}}
+)
+
+// hiLo ranks high hands like hold'em and low hands like Ace-to-Five lowball
+type hiLo struct {
+	name      string
+	holeCards int
+	hands     func(hole []card.Card, board []card.Card) (hands, error)
+}
+
+func (h hiLo) Name() string {
+	return h.name
+}
+
+func (hiLo) Deck() *card.Deck {
+	return card.NewDeck()
+}
+
+func (h hiLo) HoleCards() int {
+	return h.holeCards
+}
+
+func (h hiLo) BestSplit(hole []card.Card, board []card.Card) (Split, error) {
+	each, err := h.hands(hole, board)
+	if err != nil {
+		return Split{}, err
+	}
+	high, err := bestOf(Holdem.Evaluate, each)
+	if err != nil {
+		return Split{}, err
+	}
+	low, err := bestOf(QualifyingLow, each)
+	if err != nil {
+		return Split{}, err
+	}
+	return Split{High: high, Low: low}, nil
+}
+
+// QualifyingLow ranks the hand like Ace-to-Five lowball, but only returns hands of five different faces
+// not above LowQualifier and nil for the others
+func QualifyingLow(hand []card.Card) (card.PokerCombination, error) {
+	low, err := AceToFive.Evaluate(hand)
+	if err != nil {
+		return nil, err
+	}
+	if low.Rank() != card.RankHighCard || low.Kickers()[0] > int(LowQualifier) {
+		return nil, nil
+	}
+	return low, nil
+}
+
+// Pot describes how chips are split between the winners
+type Pot struct {
+	Chips int
+	// Button is the seat of the dealer, the odd chips of a tie go to the winners in seat order starting
+	// with the one on the left of the button
+	Button int
+	// OddChipToLow gives the odd chip of an uneven pot to the low half, by default it goes to the high half
+	OddChipToLow bool
+}
+
+// SplitPot divides the pot between players in seat order. Half of the pot goes to the best high hands
+// and half to the best low hands, the high hands take the whole pot when no low qualifies.
+// A player with a nil high hand does not contest the pot
+func SplitPot(pot Pot, players []Split) ([]int, error) {
+	if pot.Chips < 0 {
+		return nil, errors.New(fmt.Sprintf("pot cannot have %d chips", pot.Chips))
+	}
+	if pot.Button < 0 || pot.Button >= len(players) {
+		return nil, errors.New(fmt.Sprintf("button must be one of %d seats, got %d", len(players), pot.Button))
+	}
+	highs := make([]card.PokerCombination, len(players))
+	lows := make([]card.PokerCombination, len(players))
+	for i, player := range players {
+		highs[i] = player.High
+		if player.High != nil {
+			lows[i] = player.Low
+		}
+	}
+	highWinners, lowWinners := winners(highs), winners(lows)
+	if len(highWinners) == 0 {
+		return nil, errors.New("no player contests the pot")
+	}
+
+	shares := make([]int, len(players))
+	if len(lowWinners) == 0 {
+		pot.divide(pot.Chips, highWinners, shares)
+		return shares, nil
+	}
+	high, low := pot.Chips-pot.Chips/2, pot.Chips/2
+	if pot.OddChipToLow {
+		high, low = low, high
+	}
+	pot.divide(high, highWinners, shares)
+	pot.divide(low, lowWinners, shares)
+	return shares, nil
+}
+
+// winners returns the seats of the strongest non-nil hands
+func winners(hands []card.PokerCombination) []int {
+	var best card.PokerCombination
+	var seats []int
+	for seat, hand := range hands {
+		if hand == nil {
+			continue
+		}
+		switch card.Compare(hand, best) {
+		case 1:
+			best, seats = hand, []int{seat}
+		case 0:
+			seats = append(seats, seat)
+		}
+	}
+	return seats
+}
+
+// divide adds equal parts of chips to shares of the winners, the odd chips go one by one
+// to the winners closest to the left of the button
+func (p Pot) divide(chips int, winners []int, shares []int) {
+	for _, seat := range winners {
+		shares[seat] += chips / len(winners)
+	}
+	odd := chips % len(winners)
+	for offset := 1; odd > 0; offset++ {
+		seat := (p.Button + offset) % len(shares)
+		for _, winner := range winners {
+			if winner == seat {
+				shares[seat]++
+				odd--
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package variant
+
+import (
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/card"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestQualifyingLow(t *testing.T) {
+	for _, list := range []string{"Ah,2c,3d,4s,5h", "8c,7d,6h,4s,3s", "Ac,2d,3h,4s,8s"} {
+		low, err := QualifyingLow(cardsOf(t, list))
+		require.NoError(t, err)
+		assert.NotNil(t, low, list)
+	}
+	for _, list := range []string{"9c,7d,6h,4s,3s", "Ac,Ad,2h,3s,4s", "Kc,2d,3h,4s,5s"} {
+		low, err := QualifyingLow(cardsOf(t, list))
+		require.NoError(t, err)
+		assert.Nil(t, low, list)
+	}
+	_, err := QualifyingLow(cardsOf(t, "Ac,2d,3h,4s"))
+	require.Error(t, err)
+}
+
+func TestOmahaHiLo(t *testing.T) {
+	t.Run("high and low of different cards", func(t *testing.T) {
+		split, err := OmahaHiLo.BestSplit(cardsOf(t, "Ah,2h,Kc,Kd"), cardsOf(t, "3h,7c,8d,Ks,9s"))
+		require.NoError(t, err)
+		assert.Equal(t, card.RankThreeOfAKind, split.High.Rank())
+		require.NotNil(t, split.Low)
+		assert.Equal(t, []int{8, 7, 3, 2, 1}, split.Low.Kickers())
+	})
+	t.Run("two hole cards for low", func(t *testing.T) {
+		// a single low card in hand cannot make a low with the board
+		split, err := OmahaHiLo.BestSplit(cardsOf(t, "Ah,Kh,Qc,Jd"), cardsOf(t, "2h,3c,4d,5s,6h"))
+		require.NoError(t, err)
+		assert.Nil(t, split.Low)
+	})
+	t.Run("board without low", func(t *testing.T) {
+		split, err := OmahaHiLo.BestSplit(cardsOf(t, "Ah,2h,3c,4d"), cardsOf(t, "9h,Th,Jd,5s,Kh"))
+		require.NoError(t, err)
+		assert.Nil(t, split.Low)
+		assert.Equal(t, card.RankFlush, split.High.Rank())
+	})
+}
+
+func TestStudHiLo(t *testing.T) {
+	split, err := StudHiLo.BestSplit(cardsOf(t, "Ah,2c,3d,4s,6h,6c,6d"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, card.RankThreeOfAKind, split.High.Rank())
+	require.NotNil(t, split.Low)
+	assert.Equal(t, []int{6, 4, 3, 2, 1}, split.Low.Kickers())
+	assert.Equal(t, 7, StudHiLo.HoleCards())
+}
+
+func TestSplitPot(t *testing.T) {
+	splitOf := func(hole string, board string) Split {
+		split, err := OmahaHiLo.BestSplit(cardsOf(t, hole), cardsOf(t, board))
+		require.NoError(t, err)
+		return split
+	}
+	const board = "3h,4c,5d,Ks,Kh"
+	quads := splitOf("Kc,Kd,Qh,Qc", board)
+	wheel := splitOf("Ah,2h,Jc,Td", board)
+	sameWheel := splitOf("Ad,2d,Jh,Tc", board)
+	noLow := splitOf("Qs,Qd,Jd,Ts", "Th,Jc,Kd,9s,8h")
+
+	t.Run("high and low", func(t *testing.T) {
+		shares, err := SplitPot(Pot{Chips: 101}, []Split{quads, wheel})
+		require.NoError(t, err)
+		assert.Equal(t, []int{51, 50}, shares)
+
+		shares, err = SplitPot(Pot{Chips: 101, OddChipToLow: true}, []Split{quads, wheel})
+		require.NoError(t, err)
+		assert.Equal(t, []int{50, 51}, shares)
+	})
+	t.Run("quartered", func(t *testing.T) {
+		shares, err := SplitPot(Pot{Chips: 100}, []Split{quads, wheel, sameWheel})
+		require.NoError(t, err)
+		assert.Equal(t, []int{50, 25, 25}, shares)
+
+		shares, err = SplitPot(Pot{Chips: 103, Button: 1}, []Split{quads, wheel, sameWheel})
+		require.NoError(t, err)
+		// the low half of 51 leaves an odd chip for the first winner on the left of the button
+		assert.Equal(t, []int{52, 25, 26}, shares)
+	})
+	t.Run("no low scoops", func(t *testing.T) {
+		shares, err := SplitPot(Pot{Chips: 101}, []Split{noLow, {High: noLow.High}})
+		require.NoError(t, err)
+		assert.Equal(t, []int{50, 51}, shares)
+	})
+	t.Run("folded players", func(t *testing.T) {
+		shares, err := SplitPot(Pot{Chips: 90}, []Split{{}, quads, {}})
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 90, 0}, shares)
+
+		_, err = SplitPot(Pot{Chips: 90}, []Split{{}, {}})
+		require.Error(t, err)
+	})
+	t.Run("invalid pot", func(t *testing.T) {
+		_, err := SplitPot(Pot{Chips: -1}, []Split{quads, wheel})
+		require.Error(t, err)
+		_, err = SplitPot(Pot{Chips: 10, Button: 2}, []Split{quads, wheel})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,120 @@
+package variant
+
+import (
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/card"
+)
+
+// lowballHoleCards is the hand of a draw game, there is no board
+const lowballHoleCards = card.ValidCombinationSize
+
+// worstOrder is above the order of every category, so that a weaker hand has a lower strength
+// once the strength of a high hand is subtracted from it
+const worstOrder = 1 << kickerBits
+
+// aceLowOrder ranks hands without pairs best, straights and flushes do not count
+var aceLowOrder = map[int]int{
+	card.RankHighCard:     1,
+	card.RankPair:         2,
+	card.RankTwoPairs:     3,
+	card.RankThreeOfAKind: 4,
+	card.RankFullHouse:    5,
+	card.RankFourOfAKind:  6,
+}
+
+// aceToFive is Ace-to-Five lowball: the ace is the lowest card, straights and flushes
+// are ignored, so 5-4-3-2-A is the best hand
+type aceToFive struct{}
+
+func (aceToFive) Name() string {
+	return "ace-to-five"
+}
+
+func (aceToFive) Deck() *card.Deck {
+	return card.NewDeck()
+}
+
+func (aceToFive) HoleCards() int {
+	return lowballHoleCards
+}
+
+func (aceToFive) Evaluate(hand []card.Card) (card.PokerCombination, error) {
+	if err := validateHand(hand, card.Face2); err != nil {
+		return nil, err
+	}
+	values := make([]int, len(hand))
+	for i, c := range hand {
+		values[i] = c.NumericValue()
+		if c.Face == card.FaceAce {
+			values[i] = 1
+		}
+	}
+	groups, kickers := groupFaces(values)
+	rank := card.RankHighCard
+	switch {
+	case groups[0] == 5:
+		rank = card.RankFiveOfAKind
+	case groups[0] == 4:
+		rank = card.RankFourOfAKind
+	case groups[0] == 3 && groups[1] == 2:
+		rank = card.RankFullHouse
+	case groups[0] == 3:
+		rank = card.RankThreeOfAKind
+	case groups[0] == 2 && groups[1] == 2:
+		rank = card.RankTwoPairs
+	case groups[0] == 2:
+		rank = card.RankPair
+	}
+	order, err := orderOf(aceLowOrder, rank)
+	if err != nil {
+		return nil, err
+	}
+	return lowCombination(hand, rank, order, kickers), nil
+}
+
+func (a aceToFive) Best(hole []card.Card, board []card.Card) (card.PokerCombination, error) {
+	return bestOfAny(a, hole, board)
+}
+
+// deuceToSeven is Deuce-to-Seven lowball: hands are ranked like in high poker, the ace is always high
+// and the lowest hand wins, so 7-5-4-3-2 of different suits is the best hand
+type deuceToSeven struct{}
+
+func (deuceToSeven) Name() string {
+	return "deuce-to-seven"
+}
+
+func (deuceToSeven) Deck() *card.Deck {
+	return card.NewDeck()
+}
+
+func (deuceToSeven) HoleCards() int {
+	return lowballHoleCards
+}
+
+func (deuceToSeven) Evaluate(hand []card.Card) (card.PokerCombination, error) {
+	high, err := Holdem.Evaluate(hand)
+	if err != nil {
+		return nil, err
+	}
+	rank, kickers := high.Rank(), high.Kickers()
+	wheel := (rank == card.RankStraight || rank == card.RankStraightFlush) && kickers[0] == int(card.Face5)
+	if wheel {
+		// the ace is high, so A-5-4-3-2 is not a straight
+		rank, kickers = card.RankHighCard, []int{int(card.FaceAce), 5, 4, 3, 2}
+		if high.Rank() == card.RankStraightFlush {
+			rank = card.RankFlush
+		}
+	}
+	return lowCombination(hand, rank, rank, kickers), nil
+}
+
+func (d deuceToSeven) Best(hole []card.Card, board []card.Card) (card.PokerCombination, error) {
+	return bestOfAny(d, hole, board)
+}
+
+// lowCombination inverts the strength of a high hand, so that the lowest hand is the strongest one
+func lowCombination(hand []card.Card, rank int, order int, kickers []int) combination {
+	low := newCombination(hand, rank, order, kickers)
+	low.strength = strengthOf(worstOrder, nil) - low.strength
+	return low
+}
@@ -0,0 +1,134 @@
+package variant
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/card"
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/combinatorics"
+	"strings"
+)
+
+// Rules are what tells poker variants apart: the deck, how many hole cards a player gets,
+// how five cards are ranked and which cards a player may combine into a hand.
+// A higher Strength of a combination is always better, lowball variants included
+type Rules interface {
+	Name() string
+	// Deck returns a new deck of the variant in a fixed order
+	Deck() *card.Deck
+	// HoleCards is the count of private cards of a player
+	HoleCards() int
+	// Evaluate ranks a hand of card.ValidCombinationSize cards of the deck.
+	// Like card.CombinationOf, the cards are kept by the combination without copying
+	Evaluate(hand []card.Card) (card.PokerCombination, error)
+	// Best returns the best combination a player makes out of the hole cards and the board
+	Best(hole []card.Card, board []card.Card) (card.PokerCombination, error)
+}
+
+var (
+	Holdem       Rules = holdem{}
+	ShortDeck    Rules = shortDeck{}
+	Omaha        Rules = omaha{}
+	AceToFive    Rules = aceToFive{}
+	DeuceToSeven Rules = deuceToSeven{}
+)
+
+// All returns every supported variant
+func All() []Rules {
+	return []Rules{Holdem, ShortDeck, Omaha, AceToFive, DeuceToSeven}
+}
+
+// Names returns the names of every supported variant
+func Names() []string {
+	var names []string
+	for _, rules := range All() {
+		names = append(names, rules.Name())
+	}
+	return names
+}
+
+// ByName returns the variant with the given name, ignoring case
+func ByName(name string) (Rules, error) {
+	for _, rules := range All() {
+		if strings.EqualFold(rules.Name(), name) {
+			return rules, nil
+		}
+	}
+	return nil, errors.New(fmt.Sprintf("unsupported variant %s, use one of %s", name, strings.Join(Names(), ", ")))
+}
+
+// validateHand checks that the hand has the size of a combination and only distinct cards of a deck
+// without the faces below lowest. Variants have no wild cards, so Five of a Kind cannot be made
+func validateHand(hand []card.Card, lowest card.Face) error {
+	if len(hand) != card.ValidCombinationSize {
+		return errors.New(fmt.Sprintf("hand must have %d cards, got %d", card.ValidCombinationSize, len(hand)))
+	}
+	seen := map[card.Card]bool{}
+	for _, c := range hand {
+		if !c.Suit.IsValid() || !c.Face.IsValid() || c.Face < lowest {
+			return errors.New(fmt.Sprintf("card %s is not in the deck", c))
+		}
+		if seen[c] {
+			return errors.New(fmt.Sprintf("card %s is repeated", c))
+		}
+		seen[c] = true
+	}
+	return nil
+}
+
+// orderOf returns the order of the category in a variant, categories the variant does not rank are an error
+func orderOf(orders map[int]int, rank int) (int, error) {
+	order, ok := orders[rank]
+	if !ok {
+		return 0, errors.New(fmt.Sprintf("%s is not ranked by the variant", card.NameOf(rank)))
+	}
+	return order, nil
+}
+
+// hands enumerates the hands a player may make, passing a new slice of every hand to yield
+// until it returns false
+type hands func(yield func(hand []card.Card) bool) error
+
+// anyFive makes hands of any card.ValidCombinationSize of the cards
+func anyFive(cards []card.Card) (hands, error) {
+	if len(cards) < card.ValidCombinationSize {
+		return nil, errors.New(fmt.Sprintf("at least %d cards are required, got %d", card.ValidCombinationSize, len(cards)))
+	}
+	return func(yield func(hand []card.Card) bool) error {
+		return combinatorics.EachCombination(cards, card.ValidCombinationSize, func(hand []card.Card) bool {
+			return yield(append([]card.Card(nil), hand...))
+		})
+	}, nil
+}
+
+// bestOf evaluates every hand and returns the strongest of them
+func bestOf(evaluate func(hand []card.Card) (card.PokerCombination, error), each hands) (card.PokerCombination, error) {
+	var best card.PokerCombination
+	var evaluationErr error
+	err := each(func(hand []card.Card) bool {
+		var combination card.PokerCombination
+		combination, evaluationErr = evaluate(hand)
+		if evaluationErr != nil {
+			return false
+		}
+		if card.Compare(combination, best) > 0 {
+			best = combination
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if evaluationErr != nil {
+		return nil, evaluationErr
+	}
+	return best, nil
+}
+
+// bestOfAny returns the strongest hand of any card.ValidCombinationSize of the hole and board cards
+func bestOfAny(rules Rules, hole []card.Card, board []card.Card) (card.PokerCombination, error) {
+	each, err := anyFive(append(append([]card.Card(nil), hole...), board...))
+	if err != nil {
+		return nil, err
+	}
+	return bestOf(rules.Evaluate, each)
+}
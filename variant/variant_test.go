@@ -0,0 +1,143 @@
+package variant
+
+import (
+	"github.com/Kolesa-Education/kolesa-upgrade-homework-8-reference-implementation/card"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func cardsOf(t *testing.T, list string) []card.Card {
+	t.Helper()
+	cards, err := card.ParseList(card.NotationAuto, list)
+	require.NoError(t, err)
+	return cards
+}
+
+func evaluate(t *testing.T, rules Rules, list string) card.PokerCombination {
+	t.Helper()
+	combination, err := rules.Evaluate(cardsOf(t, list))
+	require.NoError(t, err)
+	return combination
+}
+
+func TestByName(t *testing.T) {
+	for _, rules := range All() {
+		found, err := ByName(rules.Name())
+		require.NoError(t, err)
+		assert.Equal(t, rules, found)
+	}
+	_, err := ByName("razz")
+	require.Error(t, err)
+}
+
+func TestRepeatedCards(t *testing.T) {
+	for _, rules := range All() {
+		_, err := rules.Evaluate(cardsOf(t, "As,As,As,As,As"))
+		assert.Error(t, err, rules.Name())
+		_, err = rules.Evaluate(cardsOf(t, "9s,9s,Ts,Js,Qs"))
+		assert.Error(t, err, rules.Name())
+	}
+	for _, orders := range []map[int]int{shortDeckOrder, aceLowOrder} {
+		_, err := orderOf(orders, card.RankFiveOfAKind)
+		assert.Error(t, err)
+	}
+}
+
+func TestHoldem(t *testing.T) {
+	assert.Equal(t, card.StandardDeckSize, Holdem.Deck().Remaining())
+	flush := evaluate(t, Holdem, "2s,5s,9s,Js,Ks")
+	fullHouse := evaluate(t, Holdem, "2s,2h,9s,9d,9c")
+	assert.Equal(t, 1, card.Compare(fullHouse, flush))
+
+	best, err := Holdem.Best(cardsOf(t, "Ah,Kh"), cardsOf(t, "Qh,Jh,Th,2c,3d"))
+	require.NoError(t, err)
+	assert.Equal(t, card.CombinationRoyalFlush, best.Name())
+	representation, err := best.Representation()
+	require.NoError(t, err)
+	assert.Equal(t, "♥A,♥K,♥Q,♥J,♥10 | Royal Flush", representation)
+}
+
+func TestShortDeck(t *testing.T) {
+	t.Run("deck starts at six", func(t *testing.T) {
+		deck := ShortDeck.Deck()
+		assert.Equal(t, 36, deck.Remaining())
+		for _, c := range deck.Cards() {
+			assert.GreaterOrEqual(t, c.Face, card.Face6)
+		}
+		_, err := ShortDeck.Evaluate(cardsOf(t, "2s,7s,8s,9s,Ts"))
+		require.Error(t, err)
+	})
+	t.Run("flush beats full house", func(t *testing.T) {
+		flush := evaluate(t, ShortDeck, "6s,8s,9s,Js,Ks")
+		fullHouse := evaluate(t, ShortDeck, "Ah,As,Ad,Kd,Kc")
+		assert.Equal(t, card.RankFlush, flush.Rank())
+		assert.Equal(t, 1, card.Compare(flush, fullHouse))
+		assert.Equal(t, -1, card.Compare(flush, evaluate(t, ShortDeck, "7s,7h,7d,7c,6c")))
+	})
+	t.Run("ace plays low with six", func(t *testing.T) {
+		wheel := evaluate(t, ShortDeck, "Ah,6s,7d,8c,9c")
+		assert.Equal(t, card.RankStraight, wheel.Rank())
+		assert.Equal(t, []int{9}, wheel.Kickers())
+		assert.Equal(t, 1, card.Compare(wheel, evaluate(t, ShortDeck, "Ah,As,Ad,9d,8c")))
+		assert.Equal(t, -1, card.Compare(wheel, evaluate(t, ShortDeck, "6h,7s,8d,9c,Tc")))
+
+		straightFlush := evaluate(t, ShortDeck, "Ah,6h,7h,8h,9h")
+		assert.Equal(t, card.RankStraightFlush, straightFlush.Rank())
+	})
+}
+
+func TestOmaha(t *testing.T) {
+	assert.Equal(t, 4, Omaha.HoleCards())
+	t.Run("exactly two hole cards", func(t *testing.T) {
+		// four hearts on board do not make a flush with a single heart in hand
+		best, err := Omaha.Best(cardsOf(t, "Ah,Kc,Qd,2s"), cardsOf(t, "3h,7h,9h,Jh,4c"))
+		require.NoError(t, err)
+		assert.NotEqual(t, card.RankFlush, best.Rank())
+		assert.Equal(t, card.RankHighCard, best.Rank())
+
+		holdem, err := Holdem.Best(cardsOf(t, "Ah,Kc"), cardsOf(t, "3h,7h,9h,Jh,4c"))
+		require.NoError(t, err)
+		assert.Equal(t, card.RankFlush, holdem.Rank())
+	})
+	t.Run("exactly three board cards", func(t *testing.T) {
+		// quads in hand only play as a pair
+		best, err := Omaha.Best(cardsOf(t, "Ah,As,Ad,Ac"), cardsOf(t, "2h,7s,9d"))
+		require.NoError(t, err)
+		assert.Equal(t, card.RankPair, best.Rank())
+	})
+	t.Run("not enough cards", func(t *testing.T) {
+		_, err := Omaha.Best(cardsOf(t, "Ah,As,Ad,Ac"), cardsOf(t, "2h,7s"))
+		require.Error(t, err)
+	})
+}
+
+func TestAceToFive(t *testing.T) {
+	wheel := evaluate(t, AceToFive, "Ah,2h,3h,4h,5h")
+	assert.Equal(t, card.RankHighCard, wheel.Rank())
+	assert.Equal(t, []int{5, 4, 3, 2, 1}, wheel.Kickers())
+
+	sixLow := evaluate(t, AceToFive, "6c,4d,3h,2s,As")
+	pair := evaluate(t, AceToFive, "Ac,Ad,2h,3s,4s")
+	kingLow := evaluate(t, AceToFive, "Kc,Qd,Jh,Ts,9s")
+	assert.Equal(t, 1, card.Compare(wheel, sixLow))
+	assert.Equal(t, 1, card.Compare(kingLow, pair))
+	assert.Equal(t, 1, card.Compare(pair, evaluate(t, AceToFive, "2c,2d,3h,3s,4s")))
+
+	best, err := AceToFive.Best(cardsOf(t, "Kc,Kd,5h,4s,3s"), cardsOf(t, "2c,Ac"))
+	require.NoError(t, err)
+	assert.Equal(t, []int{5, 4, 3, 2, 1}, best.Kickers())
+}
+
+func TestDeuceToSeven(t *testing.T) {
+	best := evaluate(t, DeuceToSeven, "7c,5d,4h,3s,2s")
+	assert.Equal(t, 1, card.Compare(best, evaluate(t, DeuceToSeven, "8c,5d,4h,3s,2s")))
+	assert.Equal(t, 1, card.Compare(best, evaluate(t, DeuceToSeven, "7s,5s,4s,3s,2s")), "flush counts")
+	assert.Equal(t, 1, card.Compare(best, evaluate(t, DeuceToSeven, "6c,5d,4h,3s,2s")), "straight counts")
+
+	wheel := evaluate(t, DeuceToSeven, "Ac,5d,4h,3s,2s")
+	assert.Equal(t, card.RankHighCard, wheel.Rank())
+	assert.Equal(t, 1, card.Compare(wheel, evaluate(t, DeuceToSeven, "Ac,6d,4h,3s,2s")))
+	assert.Equal(t, -1, card.Compare(wheel, evaluate(t, DeuceToSeven, "Kc,Qd,Jh,9s,8s")))
+	assert.Equal(t, 1, card.Compare(evaluate(t, DeuceToSeven, "Ac,Kd,Qh,Js,9s"), evaluate(t, DeuceToSeven, "2c,2d,3h,4s,5s")))
+}